@@ -0,0 +1,162 @@
+// Command devserver watches this project's Go sources and rebuilds and
+// restarts cmd/server automatically, so iterating on a handler doesn't
+// require manually stopping, rebuilding, and relaunching the API by hand.
+// It is a development-only tool: run it instead of `go run ./cmd/server`
+// while working on internal/http, and leave it out of any deployed image.
+//
+// This project serves JSON only - there are no HTML templates to inject a
+// reload script into, so there is nothing for this tool to watch beyond Go
+// source. cmd/server separately exposes a `/__dev/reload` SSE endpoint
+// (gated behind config.Debug) that a browser-side client can use to detect
+// a restart and reload itself.
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// watchDirs are walked recursively for .go files to watch. internal/http
+// holds the handlers this tool exists to speed up iteration on; cmd/server
+// is included too since main.go itself wires those handlers up.
+var watchDirs = []string{"internal/http", "cmd/server"}
+
+// debounce coalesces a burst of filesystem events (e.g. an editor's save
+// touching several files, or a save-triggered gofmt rewrite) into a single
+// rebuild.
+const debounce = 300 * time.Millisecond
+
+// shutdownTimeout bounds how long devserver waits for the running server
+// to exit after SIGTERM before killing it outright.
+const shutdownTimeout = 10 * time.Second
+
+func main() {
+	binPath := filepath.Join(os.TempDir(), "genkitgo-devserver")
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		log.Fatalf("failed to create watcher: %v", err)
+	}
+	defer watcher.Close()
+
+	for _, dir := range watchDirs {
+		if err := addRecursive(watcher, dir); err != nil {
+			log.Fatalf("failed to watch %s: %v", dir, err)
+		}
+	}
+
+	proc, err := buildAndStart(binPath)
+	if err != nil {
+		log.Fatalf("initial build failed: %v", err)
+	}
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+
+	timer := time.NewTimer(0)
+	if !timer.Stop() {
+		<-timer.C
+	}
+
+	for {
+		select {
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+			if filepath.Ext(event.Name) != ".go" {
+				continue
+			}
+			timer.Reset(debounce)
+
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return
+			}
+			log.Printf("watcher error: %v", err)
+
+		case <-timer.C:
+			log.Println("change detected, rebuilding...")
+			stop(proc)
+			proc, err = buildAndStart(binPath)
+			if err != nil {
+				log.Printf("rebuild failed, keeping previous binary stopped: %v", err)
+				continue
+			}
+
+		case sig := <-sigCh:
+			log.Printf("received %s, shutting down", sig)
+			stop(proc)
+			return
+		}
+	}
+}
+
+// addRecursive adds dir and every subdirectory under it to watcher, since
+// fsnotify only watches a single directory level at a time.
+func addRecursive(watcher *fsnotify.Watcher, dir string) error {
+	return filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return watcher.Add(path)
+		}
+		return nil
+	})
+}
+
+// buildAndStart compiles cmd/server to binPath and launches it, streaming
+// its output to this process's own stdout/stderr.
+func buildAndStart(binPath string) (*exec.Cmd, error) {
+	build := exec.Command("go", "build", "-o", binPath, "./cmd/server")
+	build.Stdout = os.Stdout
+	build.Stderr = os.Stderr
+	if err := build.Run(); err != nil {
+		return nil, err
+	}
+
+	cmd := exec.Command(binPath)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+	log.Printf("server started (pid %d)", cmd.Process.Pid)
+	return cmd, nil
+}
+
+// stop asks proc to shut down gracefully via SIGTERM - the same signal
+// cmd/server's own signal.Notify handler expects - falling back to Kill if
+// it doesn't exit within shutdownTimeout.
+func stop(proc *exec.Cmd) {
+	if proc == nil || proc.Process == nil {
+		return
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- proc.Wait() }()
+
+	if err := proc.Process.Signal(syscall.SIGTERM); err != nil {
+		log.Printf("failed to signal server: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		log.Println("server did not exit in time, killing it")
+		proc.Process.Kill()
+		<-done
+	}
+}
@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/plobin/genkitgo/internal/services/Block/DeleteBlock"
+	"github.com/spf13/cobra"
+)
+
+var deleteBlockCmd = &cobra.Command{
+	Use:   "delete-block <request-id> <block-id>",
+	Short: "Delete a single indexed block",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		blockID, err := strconv.Atoi(args[1])
+		if err != nil {
+			return fmt.Errorf("invalid block id %q: %w", args[1], err)
+		}
+
+		store, err := openStore()
+		if err != nil {
+			return fmt.Errorf("failed to open OCR store: %w", err)
+		}
+		defer store.Close()
+
+		if err := DeleteBlock.NewService(store).Execute(cmd.Context(), args[0], blockID); err != nil {
+			return fmt.Errorf("delete-block failed: %w", err)
+		}
+		return writeJSON(cmd.OutOrStdout(), map[string]bool{"success": true})
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(deleteBlockCmd)
+}
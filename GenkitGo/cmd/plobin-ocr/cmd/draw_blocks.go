@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/plobin/genkitgo/internal/services/Page/GetPage"
+	"github.com/plobin/genkitgo/internal/services/Visualization/DrawBlocks"
+	"github.com/spf13/cobra"
+)
+
+var drawBlocksOutputPath string
+
+var drawBlocksCmd = &cobra.Command{
+	Use:   "draw-blocks <image-path> <request-id> <page-number>",
+	Short: "Draw an already-indexed page's blocks onto its source image",
+	Args:  cobra.ExactArgs(3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		pageNumber, err := strconv.Atoi(args[2])
+		if err != nil {
+			return fmt.Errorf("invalid page number %q: %w", args[2], err)
+		}
+
+		store, err := openStore()
+		if err != nil {
+			return fmt.Errorf("failed to open OCR store: %w", err)
+		}
+		defer store.Close()
+
+		page, err := GetPage.NewService(store).Execute(cmd.Context(), args[1], pageNumber)
+		if err != nil {
+			return fmt.Errorf("draw-blocks failed: %w", err)
+		}
+
+		outputPath := drawBlocksOutputPath
+		if outputPath == "" {
+			outputPath = args[0] + ".annotated.png"
+		}
+
+		if err := DrawBlocks.NewService().Execute(cmd.Context(), args[0], page.Blocks, outputPath); err != nil {
+			return fmt.Errorf("draw-blocks failed: %w", err)
+		}
+
+		fmt.Fprintln(cmd.OutOrStdout(), outputPath)
+		return nil
+	},
+}
+
+func init() {
+	drawBlocksCmd.Flags().StringVarP(&drawBlocksOutputPath, "output-path", "o", "", "annotated PNG output path (defaults to <image-path>.annotated.png)")
+	rootCmd.AddCommand(drawBlocksCmd)
+}
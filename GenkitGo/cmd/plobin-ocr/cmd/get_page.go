@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/plobin/genkitgo/internal/services/Page/GetPage"
+	"github.com/spf13/cobra"
+)
+
+var getPageCmd = &cobra.Command{
+	Use:   "get-page <request-id> <page-number>",
+	Short: "Fetch a single indexed page's blocks",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		pageNumber, err := strconv.Atoi(args[1])
+		if err != nil {
+			return fmt.Errorf("invalid page number %q: %w", args[1], err)
+		}
+
+		store, err := openStore()
+		if err != nil {
+			return fmt.Errorf("failed to open OCR store: %w", err)
+		}
+		defer store.Close()
+
+		result, err := GetPage.NewService(store).Execute(cmd.Context(), args[0], pageNumber)
+		if err != nil {
+			return fmt.Errorf("get-page failed: %w", err)
+		}
+		return writeBlocks(cmd.OutOrStdout(), result.Blocks)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(getPageCmd)
+}
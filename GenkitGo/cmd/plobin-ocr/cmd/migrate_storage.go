@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/plobin/genkitgo/internal/storage"
+	"github.com/spf13/cobra"
+)
+
+var (
+	migrateStorageEndpoint  string
+	migrateStorageBucket    string
+	migrateStorageAccessKey string
+	migrateStorageSecretKey string
+	migrateStorageUseSSL    bool
+	migrateStoragePrefix    string
+)
+
+var migrateStorageCmd = &cobra.Command{
+	Use:   "migrate-storage",
+	Short: "Copy --base-dir's local object tree onto an S3-compatible bucket",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		src, err := storage.NewLocalDriver(baseDir)
+		if err != nil {
+			return fmt.Errorf("failed to open source storage: %w", err)
+		}
+
+		dst, err := storage.NewS3Driver(cmd.Context(), migrateStorageEndpoint, migrateStorageBucket, migrateStorageAccessKey, migrateStorageSecretKey, migrateStorageUseSSL)
+		if err != nil {
+			return fmt.Errorf("failed to open destination storage: %w", err)
+		}
+
+		count, err := storage.Migrate(cmd.Context(), src, dst, migrateStoragePrefix)
+		if err != nil {
+			return fmt.Errorf("migration failed: %w", err)
+		}
+
+		fmt.Fprintf(cmd.OutOrStdout(), "copied %d objects from %s to s3://%s\n", count, baseDir, migrateStorageBucket)
+		return nil
+	},
+}
+
+func init() {
+	migrateStorageCmd.Flags().StringVar(&migrateStorageEndpoint, "s3-endpoint", "", "S3-compatible endpoint (host:port)")
+	migrateStorageCmd.Flags().StringVar(&migrateStorageBucket, "s3-bucket", "", "destination bucket")
+	migrateStorageCmd.Flags().StringVar(&migrateStorageAccessKey, "s3-access-key", "", "destination access key")
+	migrateStorageCmd.Flags().StringVar(&migrateStorageSecretKey, "s3-secret-key", "", "destination secret key")
+	migrateStorageCmd.Flags().BoolVar(&migrateStorageUseSSL, "s3-use-ssl", true, "use TLS when connecting to the destination endpoint")
+	migrateStorageCmd.Flags().StringVar(&migrateStoragePrefix, "prefix", "", "only migrate keys under this prefix")
+	rootCmd.AddCommand(migrateStorageCmd)
+}
@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/plobin/genkitgo/internal/models"
+	"github.com/plobin/genkitgo/internal/services/OCR/Store"
+)
+
+// writeJSON renders any JSON-serializable result. ndjson/csv don't apply to
+// single-object results (a template, a delete ack), so it always emits one
+// JSON document regardless of --output.
+func writeJSON(w io.Writer, v interface{}) error {
+	return json.NewEncoder(w).Encode(v)
+}
+
+// writeBlocks renders a slice of blocks in the format requested by --output.
+func writeBlocks(w io.Writer, blocks []models.BlockInfo) error {
+	switch outputFormat {
+	case "ndjson":
+		enc := json.NewEncoder(w)
+		for _, b := range blocks {
+			if err := enc.Encode(b); err != nil {
+				return err
+			}
+		}
+		return nil
+	case "csv":
+		cw := csv.NewWriter(w)
+		defer cw.Flush()
+		if err := cw.Write([]string{"id", "type", "text", "confidence", "x", "y", "width", "height"}); err != nil {
+			return err
+		}
+		for _, b := range blocks {
+			if err := cw.Write([]string{
+				fmt.Sprint(b.ID),
+				string(b.BlockType),
+				b.Text,
+				fmt.Sprintf("%.4f", b.Confidence),
+				fmt.Sprint(b.BBox.X),
+				fmt.Sprint(b.BBox.Y),
+				fmt.Sprint(b.BBox.Width),
+				fmt.Sprint(b.BBox.Height),
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return json.NewEncoder(w).Encode(blocks)
+	}
+}
+
+// writeSearchHits renders search hits in the format requested by --output.
+func writeSearchHits(w io.Writer, hits []Store.SearchHit) error {
+	switch outputFormat {
+	case "ndjson":
+		enc := json.NewEncoder(w)
+		for _, h := range hits {
+			if err := enc.Encode(h); err != nil {
+				return err
+			}
+		}
+		return nil
+	case "csv":
+		cw := csv.NewWriter(w)
+		defer cw.Flush()
+		if err := cw.Write([]string{"block_id", "request_id", "page_number", "block_type", "confidence", "snippet"}); err != nil {
+			return err
+		}
+		for _, h := range hits {
+			if err := cw.Write([]string{
+				fmt.Sprint(h.BlockID),
+				h.RequestID,
+				fmt.Sprint(h.PageNumber),
+				h.BlockType,
+				fmt.Sprintf("%.4f", h.Confidence),
+				h.Snippet,
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return json.NewEncoder(w).Encode(hits)
+	}
+}
@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/plobin/genkitgo/internal/services/OCR/ExtractBlocks"
+	"github.com/spf13/cobra"
+)
+
+var processImageCmd = &cobra.Command{
+	Use:   "process-image <image-path>",
+	Short: "Run OCR on a single image",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		service := ExtractBlocks.NewServiceWithBackend(language, backend)
+		result, err := service.Execute(cmd.Context(), args[0],
+			ExtractBlocks.WithConfidenceThreshold(confidence),
+			ExtractBlocks.WithLanguage(language),
+			ExtractBlocks.WithSuryaEndpoint(suryaURL),
+			ExtractBlocks.WithBackend(backend),
+		)
+		if err != nil {
+			return fmt.Errorf("process-image failed: %w", err)
+		}
+		return writeBlocks(cmd.OutOrStdout(), result.Blocks)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(processImageCmd)
+}
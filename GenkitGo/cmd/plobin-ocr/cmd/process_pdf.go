@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/plobin/genkitgo/internal/models"
+	"github.com/plobin/genkitgo/internal/services/PDF/ProcessPDF"
+	"github.com/plobin/genkitgo/internal/services/Visualization/CropBlocks"
+	"github.com/spf13/cobra"
+)
+
+var processPDFCmd = &cobra.Command{
+	Use:   "process-pdf <pdf-path>",
+	Short: "Run OCR on every page of a PDF",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		store, err := openStore()
+		if err != nil {
+			return fmt.Errorf("failed to open OCR store: %w", err)
+		}
+		defer store.Close()
+
+		cropService := CropBlocks.NewService(baseDir)
+		service := ProcessPDF.NewServiceWithBackend(language, dpi, backend, store, cropService)
+
+		requestID := uuid.New().String()
+		result, err := service.Execute(cmd.Context(), args[0], requestID,
+			ProcessPDF.WithConfidenceThreshold(confidence),
+			ProcessPDF.WithLanguage(language),
+			ProcessPDF.WithSuryaEndpoint(suryaURL),
+			ProcessPDF.WithDPI(dpi),
+			ProcessPDF.WithBackend(backend),
+		)
+		if err != nil {
+			return fmt.Errorf("process-pdf failed: %w", err)
+		}
+
+		out := cmd.OutOrStdout()
+		switch outputFormat {
+		case "ndjson":
+			enc := json.NewEncoder(out)
+			for _, page := range result.Pages {
+				for _, block := range page.Blocks {
+					if err := enc.Encode(block); err != nil {
+						return err
+					}
+				}
+			}
+			return nil
+		case "csv":
+			var blocks []models.BlockInfo
+			for _, page := range result.Pages {
+				blocks = append(blocks, page.Blocks...)
+			}
+			return writeBlocks(out, blocks)
+		default:
+			return json.NewEncoder(out).Encode(result)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(processPDFCmd)
+}
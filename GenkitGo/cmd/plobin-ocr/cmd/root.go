@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"path/filepath"
+
+	"github.com/plobin/genkitgo/internal/services/OCR/Store"
+	"github.com/spf13/cobra"
+)
+
+// Global flags shared by every subcommand, mapping onto the same options
+// structs (ExtractBlocksOptions, ProcessPDFOptions, ...) the HTTP handlers
+// build from request bodies.
+var (
+	outputFormat string
+	suryaURL     string
+	dpi          float64
+	language     string
+	confidence   float64
+	baseDir      string
+	backend      string
+)
+
+var rootCmd = &cobra.Command{
+	Use:   "plobin-ocr",
+	Short: "Scriptable CLI over the OCR services, without the HTTP server",
+	Long: `plobin-ocr calls the same service constructors cmd/server wires into
+the HTTP mux (ExtractBlocks.NewService, ProcessPDF.NewService, ...)
+directly, so scripted pipelines don't need the server running.`,
+}
+
+// Execute runs the root command; main() only needs to report its error.
+func Execute() error {
+	return rootCmd.Execute()
+}
+
+func init() {
+	rootCmd.PersistentFlags().StringVar(&outputFormat, "output", "json", "output format: json|ndjson|csv")
+	rootCmd.PersistentFlags().StringVar(&suryaURL, "surya-url", "http://localhost:6004", "Surya OCR service base URL")
+	rootCmd.PersistentFlags().Float64Var(&dpi, "dpi", 150.0, "PDF rasterization DPI")
+	rootCmd.PersistentFlags().StringVar(&language, "language", "kor+eng", "OCR language hint")
+	rootCmd.PersistentFlags().Float64Var(&confidence, "confidence", 0.5, "minimum block confidence")
+	rootCmd.PersistentFlags().StringVar(&baseDir, "base-dir", "output", "base directory for the SQLite OCR index and crops")
+	rootCmd.PersistentFlags().StringVar(&backend, "backend", "surya", "OCR backend: surya|google_vision|aws_textract|azure_di")
+}
+
+// openStore opens the same SQLite-backed OCR index, rooted at --base-dir,
+// that cmd/server shares across SearchBlocks/ListRequests/GetPage/etc.
+func openStore() (*Store.Service, error) {
+	return Store.NewService(filepath.Join(baseDir, "ocr_index.db"))
+}
@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/plobin/genkitgo/internal/services/OCR/SearchBlocks"
+	"github.com/plobin/genkitgo/internal/services/OCR/Store"
+	"github.com/spf13/cobra"
+)
+
+var (
+	searchRequestID     string
+	searchBlockType     string
+	searchMinConfidence float64
+	searchLimit         int
+)
+
+var searchCmd = &cobra.Command{
+	Use:   "search <query>",
+	Short: "Full-text search across every indexed block",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		store, err := openStore()
+		if err != nil {
+			return fmt.Errorf("failed to open OCR store: %w", err)
+		}
+		defer store.Close()
+
+		hits, err := SearchBlocks.NewService(store).Execute(cmd.Context(), args[0], Store.SearchFilter{
+			RequestID:     searchRequestID,
+			BlockType:     searchBlockType,
+			MinConfidence: searchMinConfidence,
+		}, searchLimit)
+		if err != nil {
+			return fmt.Errorf("search failed: %w", err)
+		}
+		return writeSearchHits(cmd.OutOrStdout(), hits)
+	},
+}
+
+func init() {
+	searchCmd.Flags().StringVar(&searchRequestID, "request-id", "", "restrict results to one request")
+	searchCmd.Flags().StringVar(&searchBlockType, "block-type", "", "restrict results to one block type")
+	searchCmd.Flags().Float64Var(&searchMinConfidence, "min-confidence", 0, "minimum block confidence")
+	searchCmd.Flags().IntVar(&searchLimit, "limit", 20, "maximum number of hits")
+	rootCmd.AddCommand(searchCmd)
+}
@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/plobin/genkitgo/internal/models"
+	"github.com/plobin/genkitgo/internal/services/Template/CreateTemplate"
+	"github.com/plobin/genkitgo/internal/services/Template/GetTemplate"
+	"github.com/plobin/genkitgo/internal/storage"
+	"github.com/spf13/cobra"
+)
+
+var templateCmd = &cobra.Command{
+	Use:   "template",
+	Short: "Inspect and create field-extraction templates",
+}
+
+var templateGetCmd = &cobra.Command{
+	Use:   "get <template-id>",
+	Short: "Fetch a template by ID",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		store, err := storage.NewLocalDriver(baseDir)
+		if err != nil {
+			return fmt.Errorf("failed to open storage: %w", err)
+		}
+
+		template, err := GetTemplate.NewService(store).Execute(cmd.Context(), args[0])
+		if err != nil {
+			return fmt.Errorf("template get failed: %w", err)
+		}
+		return writeJSON(cmd.OutOrStdout(), template)
+	},
+}
+
+var templatePutPath string
+
+var templatePutCmd = &cobra.Command{
+	Use:   "put",
+	Short: "Create a template from a JSON TemplateCreateRequest (defaults to stdin)",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var r io.Reader = os.Stdin
+		if templatePutPath != "" && templatePutPath != "-" {
+			f, err := os.Open(templatePutPath)
+			if err != nil {
+				return fmt.Errorf("failed to open %s: %w", templatePutPath, err)
+			}
+			defer f.Close()
+			r = f
+		}
+
+		var req models.TemplateCreateRequest
+		if err := json.NewDecoder(r).Decode(&req); err != nil {
+			return fmt.Errorf("failed to parse template request: %w", err)
+		}
+
+		store, err := storage.NewLocalDriver(baseDir)
+		if err != nil {
+			return fmt.Errorf("failed to open storage: %w", err)
+		}
+
+		template, err := CreateTemplate.NewService(store).Execute(cmd.Context(), req)
+		if err != nil {
+			return fmt.Errorf("template put failed: %w", err)
+		}
+		return writeJSON(cmd.OutOrStdout(), template)
+	},
+}
+
+func init() {
+	templatePutCmd.Flags().StringVarP(&templatePutPath, "file", "f", "", "path to a TemplateCreateRequest JSON file (defaults to stdin)")
+	templateCmd.AddCommand(templateGetCmd, templatePutCmd)
+	rootCmd.AddCommand(templateCmd)
+}
@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/plobin/genkitgo/internal/services/OCR/ExtractBlocks"
+	"github.com/plobin/genkitgo/internal/services/Visualization/DrawBlocks"
+	"github.com/spf13/cobra"
+)
+
+var visualizeOutputPath string
+
+var visualizeCmd = &cobra.Command{
+	Use:   "visualize <image-path>",
+	Short: `Run OCR and draw the resulting blocks in one shot (-o - streams the PNG to stdout)`,
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		result, err := ExtractBlocks.NewService(language).Execute(cmd.Context(), args[0],
+			ExtractBlocks.WithConfidenceThreshold(confidence),
+			ExtractBlocks.WithLanguage(language),
+			ExtractBlocks.WithSuryaEndpoint(suryaURL),
+		)
+		if err != nil {
+			return fmt.Errorf("visualize failed: %w", err)
+		}
+
+		outputPath := visualizeOutputPath
+		streamToStdout := outputPath == "-"
+		if streamToStdout {
+			tmp, err := os.CreateTemp("", "visualize-*.png")
+			if err != nil {
+				return fmt.Errorf("failed to create temp file: %w", err)
+			}
+			tmp.Close()
+			defer os.Remove(tmp.Name())
+			outputPath = tmp.Name()
+		} else if outputPath == "" {
+			outputPath = args[0] + ".annotated.png"
+		}
+
+		if err := DrawBlocks.NewService().Execute(cmd.Context(), args[0], result.Blocks, outputPath); err != nil {
+			return fmt.Errorf("visualize failed: %w", err)
+		}
+
+		if !streamToStdout {
+			fmt.Fprintln(cmd.OutOrStdout(), outputPath)
+			return nil
+		}
+
+		f, err := os.Open(outputPath)
+		if err != nil {
+			return fmt.Errorf("failed to read annotated image: %w", err)
+		}
+		defer f.Close()
+
+		_, err = io.Copy(cmd.OutOrStdout(), f)
+		return err
+	},
+}
+
+func init() {
+	visualizeCmd.Flags().StringVarP(&visualizeOutputPath, "output-path", "o", "", `PNG output path, or "-" to stream to stdout`)
+	rootCmd.AddCommand(visualizeCmd)
+}
@@ -4,100 +4,227 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"image"
+	_ "image/jpeg"
+	"image/png"
 	"io"
 	"log"
 	"net/http"
 	"os"
 	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/plobin/genkitgo/internal/config"
+	cropblock "github.com/plobin/genkitgo/internal/http/controllers/Blocks/CropBlock"
+	searchcontroller "github.com/plobin/genkitgo/internal/http/controllers/OCR/Search"
+	"github.com/plobin/genkitgo/internal/jobs"
+	"github.com/plobin/genkitgo/internal/logger"
 	"github.com/plobin/genkitgo/internal/models"
+	"github.com/plobin/genkitgo/internal/observability"
 	"github.com/plobin/genkitgo/internal/services/Block/DeleteBlock"
 	"github.com/plobin/genkitgo/internal/services/Block/GetBlock"
 	"github.com/plobin/genkitgo/internal/services/Block/UpdateBlock"
 	"github.com/plobin/genkitgo/internal/services/File/Storage"
 	"github.com/plobin/genkitgo/internal/services/Image/GetImage"
+	"github.com/plobin/genkitgo/internal/services/Image/Preprocess"
+	"github.com/plobin/genkitgo/internal/services/Job/Queue"
 	"github.com/plobin/genkitgo/internal/services/LLM/Client"
+	"github.com/plobin/genkitgo/internal/services/LLM/ExtractStructured"
+	"github.com/plobin/genkitgo/internal/services/OCR/CorrectBlocks"
 	"github.com/plobin/genkitgo/internal/services/OCR/ExtractBlocks"
+	"github.com/plobin/genkitgo/internal/services/OCR/ListRequests"
+	"github.com/plobin/genkitgo/internal/services/OCR/SearchBlocks"
+	"github.com/plobin/genkitgo/internal/services/OCR/Store"
 	"github.com/plobin/genkitgo/internal/services/PDF/ProcessPDF"
 	"github.com/plobin/genkitgo/internal/services/Page/GetPage"
 	"github.com/plobin/genkitgo/internal/services/Page/ListPages"
+	"github.com/plobin/genkitgo/internal/services/Template/ApplyTemplate"
 	"github.com/plobin/genkitgo/internal/services/Template/CreateTemplate"
 	"github.com/plobin/genkitgo/internal/services/Template/DeleteTemplate"
 	"github.com/plobin/genkitgo/internal/services/Template/GetTemplate"
+	"github.com/plobin/genkitgo/internal/services/Template/LearnTemplate"
 	"github.com/plobin/genkitgo/internal/services/Template/ListTemplates"
+	"github.com/plobin/genkitgo/internal/services/Upload/UploadStore"
+	"github.com/plobin/genkitgo/internal/services/Visualization/CropBlocks"
+	"github.com/plobin/genkitgo/internal/services/Visualization/GetBlockCrop"
+	"github.com/plobin/genkitgo/internal/storage"
 )
 
 func main() {
 	// Load configuration
 	cfg := config.Load()
-	
+	logger.Init(cfg.LogFormat)
+
+	// internal/grpc/ocr has no generated stubs or server implementation yet
+	// (see its generate.go), so refuse to start rather than silently serve
+	// HTTP only while a caller believes gRPC is listening on GRPCAddr.
+	if cfg.GRPCAddr != "" {
+		log.Fatalf("GRPC_ADDR is set to %q, but the gRPC OCR service is not implemented yet (see internal/grpc/ocr/generate.go)", cfg.GRPCAddr)
+	}
+
+	otelShutdown, err := observability.Init(context.Background())
+	if err != nil {
+		log.Fatalf("Failed to initialize observability: %v", err)
+	}
+	defer func() {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := otelShutdown(shutdownCtx); err != nil {
+			log.Printf("Failed to flush traces on shutdown: %v", err)
+		}
+	}()
+
 	// Initialize services
 	llmClient := Client.NewLLMClient(cfg.LLMBaseURL, cfg.LLMAPIKey, cfg.LLMModel)
 	defer llmClient.Close()
 
+	// Redis-backed job queue (internal/jobs) for submit-and-poll processing
+	// across any number of cmd/worker processes, alongside the in-process
+	// Queue.Service below used for the SSE-driven /process-pdf flow.
+	jobsClient := jobs.NewClient(cfg.RedisAddr)
+	defer jobsClient.Close()
+
 	// Initialize OCR and PDF services (Pure Go - No Python dependency!)
-	ocrService := ExtractBlocks.NewService(cfg.OCRLanguage)
-	pdfService := ProcessPDF.NewService(cfg.OCRLanguage, 150.0)
-	storageService := Storage.NewService(cfg.OutputDir)
+	ocrService := ExtractBlocks.NewServiceWithBackend(cfg.OCRLanguage, cfg.OCREngine)
+
+	// Object storage backend (local disk or S3-compatible), selected via
+	// cfg.Storage* - see internal/storage.
+	objectStore, err := storage.New(context.Background(), storage.Config{
+		Backend:    cfg.StorageBackend,
+		LocalDir:   cfg.OutputDir,
+		Endpoint:   cfg.StorageEndpoint,
+		Bucket:     cfg.StorageBucket,
+		AccessKey:  cfg.StorageAccessKey,
+		SecretKey:  cfg.StorageSecretKey,
+		UseSSL:     cfg.StorageUseSSL,
+		CacheReads: cfg.StorageCacheReads,
+	})
+	if err != nil {
+		log.Fatalf("Failed to initialize storage backend: %v", err)
+	}
+	storageService := Storage.NewService(objectStore)
+
+	// SQLite-backed OCR index (requests/pages/blocks + full-text search)
+	store, err := Store.NewService(filepath.Join(cfg.OutputDir, "ocr_index.db"))
+	if err != nil {
+		log.Fatalf("Failed to open OCR store: %v", err)
+	}
+	defer store.Close()
+
+	cropService := CropBlocks.NewService(cfg.OutputDir)
+	pdfService := ProcessPDF.NewServiceWithBackend(cfg.OCRLanguage, 150.0, cfg.OCREngine, store, cropService)
+	jobQueueService := Queue.NewService(cfg.MaxWorkers, filepath.Join(cfg.OutputDir, "jobs"), pdfService, storageService)
+	uploadStoreService := UploadStore.NewService(filepath.Join(cfg.OutputDir, "uploads"))
+	searchBlocksService := SearchBlocks.NewService(store)
+	listOCRRequestsService := ListRequests.NewService(store)
+	getBlockCropService := GetBlockCrop.NewService(store, cfg.OutputDir)
 
 	// Block services
-	getBlockService := GetBlock.NewService(cfg.OutputDir)
-	updateBlockService := UpdateBlock.NewService(cfg.OutputDir)
-	deleteBlockService := DeleteBlock.NewService(cfg.OutputDir)
+	getBlockService := GetBlock.NewService(objectStore)
+	updateBlockService := UpdateBlock.NewService(objectStore)
+	deleteBlockService := DeleteBlock.NewService(store)
+	correctBlocksService := CorrectBlocks.NewService(llmClient, store, updateBlockService)
+	extractStructuredService := ExtractStructured.NewService(llmClient, store)
 
 	// Page services
-	getPageService := GetPage.NewService(cfg.OutputDir)
-	listPagesService := ListPages.NewService(cfg.OutputDir)
+	getPageService := GetPage.NewService(store)
+	listPagesService := ListPages.NewService(objectStore)
 
 	// Image service
-	getImageService := GetImage.NewService(cfg.OutputDir)
+	getImageService := GetImage.NewService(objectStore)
+	previewPreprocessService := Preprocess.NewService()
 
 	// Template services
-	listTemplatesService := ListTemplates.NewService(cfg.OutputDir)
-	createTemplateService := CreateTemplate.NewService(cfg.OutputDir)
-	getTemplateService := GetTemplate.NewService(cfg.OutputDir)
-	deleteTemplateService := DeleteTemplate.NewService(cfg.OutputDir)
+	listTemplatesService := ListTemplates.NewService(objectStore)
+	createTemplateService := CreateTemplate.NewService(objectStore)
+	getTemplateService := GetTemplate.NewService(objectStore)
+	deleteTemplateService := DeleteTemplate.NewService(objectStore)
+	applyTemplateService := ApplyTemplate.NewService(getTemplateService, store)
+	learnTemplateService := LearnTemplate.NewService(createTemplateService, store)
 
 	log.Printf("✅ Services initialized (Pure Go - No Python!)")
 	log.Printf("  - LLM Client (model: %s)", cfg.LLMModel)
 	log.Printf("  - OCR Service (Tesseract via gosseract)")
 	log.Printf("  - PDF Service (MuPDF via go-fitz)")
-	log.Printf("  - Storage Service (dir: %s)", cfg.OutputDir)
+	log.Printf("  - Storage Service (backend: %s)", cfg.StorageBackend)
 	log.Printf("  - Block Services (Get, Update, Delete)")
 	log.Printf("  - Page Services (Get, List)")
 	log.Printf("  - Image Service (Get)")
 	log.Printf("  - Template Services (List, Create, Get, Delete)")
-	
+
 	// Create router
 	r := chi.NewRouter()
-	
+
 	// Middleware
 	r.Use(middleware.RequestID)
 	r.Use(middleware.RealIP)
-	r.Use(middleware.Logger)
+	r.Use(logger.Middleware)
+	r.Use(observability.Middleware)
 	r.Use(middleware.Recoverer)
-	r.Use(middleware.Timeout(60 * time.Second))
-	
+
 	// Health check
 	r.Get("/", func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusOK)
 		fmt.Fprintf(w, `{"status": "ok", "message": "Genkit OCR API is running"}`)
 	})
-	
+
 	r.Get("/health", func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusOK)
 		fmt.Fprintf(w, `{"status": "healthy"}`)
 	})
-	
+
+	r.Get("/metrics", observability.Handler().ServeHTTP)
+
+	// /__dev/reload is a live-reload hook for cmd/devserver: it just holds
+	// one SSE connection open per client. When cmd/devserver rebuilds this
+	// binary it restarts the whole process, so the connection drops on its
+	// own; a reconnecting client takes that drop as its cue to reload. It's
+	// registered on the top-level router, outside the /api group's timeout
+	// below, since a devserver rebuild routinely takes longer than 60s and
+	// the timeout would otherwise kill the stream out from under a client
+	// that's just sitting there waiting to reconnect.
+	// Gated behind cfg.Debug so a production binary never exposes it.
+	if cfg.Debug {
+		r.Get("/__dev/reload", func(w http.ResponseWriter, r *http.Request) {
+			flusher, ok := w.(http.Flusher)
+			if !ok {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			w.Header().Set("Content-Type", "text/event-stream")
+			w.Header().Set("Cache-Control", "no-cache")
+			w.Header().Set("Connection", "keep-alive")
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprintf(w, "event: connected\ndata: ok\n\n")
+			flusher.Flush()
+
+			heartbeat := time.NewTicker(25 * time.Second)
+			defer heartbeat.Stop()
+			for {
+				select {
+				case <-heartbeat.C:
+					fmt.Fprintf(w, ": heartbeat\n\n")
+					flusher.Flush()
+				case <-r.Context().Done():
+					return
+				}
+			}
+		})
+	}
+
 	// API routes
 	r.Route("/api", func(r chi.Router) {
+		r.Use(middleware.Timeout(60 * time.Second))
+
 		// OCR endpoints
 		r.Post("/process-image", func(w http.ResponseWriter, r *http.Request) {
 			// Parse multipart form
@@ -147,13 +274,14 @@ func main() {
 			}
 
 			// Process with OCR
-			options := models.OCROptions{
-				MergeBlocks:    true,
-				MergeThreshold: 30,
-				Language:       cfg.OCRLanguage,
-			}
-
-			result, err := ocrService.Execute(r.Context(), tmpFile.Name(), options)
+			result, err := ocrService.Execute(r.Context(), tmpFile.Name(),
+				ExtractBlocks.WithOCROptions(models.OCROptions{
+					MergeBlocks:    true,
+					MergeThreshold: 30,
+					Language:       cfg.OCRLanguage,
+				}),
+				ExtractBlocks.WithBackend(r.URL.Query().Get("backend")),
+			)
 			if err != nil {
 				w.WriteHeader(http.StatusInternalServerError)
 				json.NewEncoder(w).Encode(models.ErrorResponse{
@@ -165,7 +293,7 @@ func main() {
 			}
 
 			// Create request in storage
-			requestID, err := storageService.CreateRequest(header.Filename, models.RequestTypeImage, header.Size, 1)
+			requestID, err := storageService.CreateRequest(r.Context(), header.Filename, models.RequestTypeImage, header.Size, 1)
 			if err != nil {
 				log.Printf("Warning: Failed to create request storage: %v", err)
 			} else {
@@ -181,6 +309,68 @@ func main() {
 			})
 		})
 
+		// Preprocessing preview: runs the deskew/binarize/denoise/upscale
+		// pipeline (internal/services/Image/Preprocess) on an uploaded image
+		// and returns the resulting PNG directly, so a caller can tune
+		// options before committing to a full process-image/process-pdf run.
+		r.Post("/preview-preprocess", func(w http.ResponseWriter, r *http.Request) {
+			if err := r.ParseMultipartForm(32 << 20); err != nil {
+				json.NewEncoder(w).Encode(models.ErrorResponse{
+					Success: false,
+					Message: "Failed to parse form",
+					Error:   err.Error(),
+				})
+				return
+			}
+
+			file, _, err := r.FormFile("file")
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				json.NewEncoder(w).Encode(models.ErrorResponse{
+					Success: false,
+					Message: "No file provided",
+					Error:   err.Error(),
+				})
+				return
+			}
+			defer file.Close()
+
+			src, _, err := image.Decode(file)
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				json.NewEncoder(w).Encode(models.ErrorResponse{
+					Success: false,
+					Message: "Failed to decode image",
+					Error:   err.Error(),
+				})
+				return
+			}
+
+			upscale, _ := strconv.ParseFloat(r.URL.Query().Get("upscale"), 64)
+			opts := Preprocess.Options{
+				Deskew:   r.URL.Query().Get("deskew") == "true",
+				Binarize: r.URL.Query().Get("binarize"),
+				Denoise:  r.URL.Query().Get("denoise") == "true",
+				Upscale:  upscale,
+			}
+
+			processed, err := previewPreprocessService.Execute(r.Context(), src, opts)
+			if err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				json.NewEncoder(w).Encode(models.ErrorResponse{
+					Success: false,
+					Message: "Preprocessing failed",
+					Error:   err.Error(),
+				})
+				return
+			}
+
+			w.Header().Set("Content-Type", "image/png")
+			if err := png.Encode(w, processed); err != nil {
+				log.Printf("Warning: Failed to encode preview-preprocess response: %v", err)
+			}
+		})
+
 		// PDF processing endpoint
 		r.Post("/process-pdf", func(w http.ResponseWriter, r *http.Request) {
 			if err := r.ParseMultipartForm(32 << 20); err != nil {
@@ -192,7 +382,7 @@ func main() {
 				return
 			}
 
-			file, header, err := r.FormFile("file")
+			file, _, err := r.FormFile("file")
 			if err != nil {
 				w.WriteHeader(http.StatusBadRequest)
 				json.NewEncoder(w).Encode(models.ErrorResponse{
@@ -204,6 +394,8 @@ func main() {
 			}
 			defer file.Close()
 
+			// Spooled to a temp file that outlives this handler - the job
+			// worker removes it once processing finishes, not us.
 			tmpFile, err := os.CreateTemp("", "upload-*.pdf")
 			if err != nil {
 				w.WriteHeader(http.StatusInternalServerError)
@@ -214,10 +406,10 @@ func main() {
 				})
 				return
 			}
-			defer os.Remove(tmpFile.Name())
 			defer tmpFile.Close()
 
 			if _, err := io.Copy(tmpFile, file); err != nil {
+				os.Remove(tmpFile.Name())
 				w.WriteHeader(http.StatusInternalServerError)
 				json.NewEncoder(w).Encode(models.ErrorResponse{
 					Success: false,
@@ -227,38 +419,308 @@ func main() {
 				return
 			}
 
-			options := models.OCROptions{
-				MergeBlocks:    true,
-				MergeThreshold: 30,
-				Language:       cfg.OCRLanguage,
+			job, err := jobQueueService.Enqueue(tmpFile.Name(), r.URL.Query().Get("backend"))
+			if err != nil {
+				os.Remove(tmpFile.Name())
+				w.WriteHeader(http.StatusServiceUnavailable)
+				json.NewEncoder(w).Encode(models.ErrorResponse{
+					Success: false,
+					Message: "Failed to queue PDF for processing",
+					Error:   err.Error(),
+				})
+				return
 			}
 
-			result, err := pdfService.Execute(r.Context(), tmpFile.Name(), options)
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusAccepted)
+			json.NewEncoder(w).Encode(models.APIResponse{
+				Success: true,
+				Message: "PDF queued for processing",
+				Data:    job,
+			})
+		})
+
+		// Job status, cancellation, and progress streaming for /process-pdf
+		r.Get("/jobs/{id}", func(w http.ResponseWriter, r *http.Request) {
+			id := chi.URLParam(r, "id")
+
+			job, err := jobQueueService.Get(id)
 			if err != nil {
-				w.WriteHeader(http.StatusInternalServerError)
+				w.WriteHeader(http.StatusNotFound)
+				json.NewEncoder(w).Encode(models.ErrorResponse{
+					Success: false,
+					Message: "Job not found",
+					Error:   err.Error(),
+				})
+				return
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(models.APIResponse{
+				Success: true,
+				Message: "Job retrieved",
+				Data: map[string]interface{}{
+					"job":         job,
+					"eta_seconds": job.ETA().Seconds(),
+				},
+			})
+		})
+
+		r.Delete("/jobs/{id}", func(w http.ResponseWriter, r *http.Request) {
+			id := chi.URLParam(r, "id")
+
+			if err := jobQueueService.Cancel(id); err != nil {
+				w.WriteHeader(http.StatusNotFound)
+				json.NewEncoder(w).Encode(models.ErrorResponse{
+					Success: false,
+					Message: "Failed to cancel job",
+					Error:   err.Error(),
+				})
+				return
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(models.APIResponse{
+				Success: true,
+				Message: "Job cancellation requested",
+				Data:    nil,
+			})
+		})
+
+		// Upload endpoints: either a one-shot streamed multipart upload, or
+		// the start of a tus-style resumable upload identified by its own ID.
+		r.Post("/uploads", func(w http.ResponseWriter, r *http.Request) {
+			contentType := r.Header.Get("Content-Type")
+
+			if strings.HasPrefix(contentType, "multipart/form-data") {
+				mr, err := r.MultipartReader()
+				if err != nil {
+					w.WriteHeader(http.StatusBadRequest)
+					json.NewEncoder(w).Encode(models.ErrorResponse{
+						Success: false,
+						Message: "Failed to read multipart upload",
+						Error:   err.Error(),
+					})
+					return
+				}
+
+				part, err := mr.NextPart()
+				if err != nil {
+					w.WriteHeader(http.StatusBadRequest)
+					json.NewEncoder(w).Encode(models.ErrorResponse{
+						Success: false,
+						Message: "No file part in upload",
+						Error:   err.Error(),
+					})
+					return
+				}
+				defer part.Close()
+
+				digest, _, size, err := uploadStoreService.StreamStore(part)
+				if err != nil {
+					w.WriteHeader(http.StatusInternalServerError)
+					json.NewEncoder(w).Encode(models.ErrorResponse{
+						Success: false,
+						Message: "Failed to store upload",
+						Error:   err.Error(),
+					})
+					return
+				}
+
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusCreated)
+				json.NewEncoder(w).Encode(models.APIResponse{
+					Success: true,
+					Message: "Upload stored",
+					Data: map[string]interface{}{
+						"sha256": digest,
+						"size":   size,
+					},
+				})
+				return
+			}
+
+			length, err := strconv.ParseInt(r.Header.Get("Upload-Length"), 10, 64)
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
 				json.NewEncoder(w).Encode(models.ErrorResponse{
 					Success: false,
-					Message: "PDF processing failed",
+					Message: "Missing or invalid Upload-Length header",
 					Error:   err.Error(),
 				})
 				return
 			}
 
-			requestID, err := storageService.CreateRequest(header.Filename, models.RequestTypePDF, header.Size, result.TotalPages)
+			upload, err := uploadStoreService.Create(length)
 			if err != nil {
-				log.Printf("Warning: Failed to create request storage: %v", err)
-			} else {
-				result.RequestID = requestID
+				w.WriteHeader(http.StatusInternalServerError)
+				json.NewEncoder(w).Encode(models.ErrorResponse{
+					Success: false,
+					Message: "Failed to start resumable upload",
+					Error:   err.Error(),
+				})
+				return
 			}
 
+			w.Header().Set("Location", "/api/uploads/"+upload.ID)
+			w.Header().Set("Upload-Length", strconv.FormatInt(upload.Length, 10))
+			w.Header().Set("Upload-Offset", strconv.FormatInt(upload.Offset, 10))
 			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusCreated)
 			json.NewEncoder(w).Encode(models.APIResponse{
 				Success: true,
-				Message: "PDF processed successfully",
-				Data:    result,
+				Message: "Resumable upload created",
+				Data:    upload,
 			})
 		})
 
+		r.Patch("/uploads/{id}", func(w http.ResponseWriter, r *http.Request) {
+			id := chi.URLParam(r, "id")
+
+			offset, err := strconv.ParseInt(r.Header.Get("Upload-Offset"), 10, 64)
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				json.NewEncoder(w).Encode(models.ErrorResponse{
+					Success: false,
+					Message: "Missing or invalid Upload-Offset header",
+					Error:   err.Error(),
+				})
+				return
+			}
+
+			upload, err := uploadStoreService.Append(id, offset, r.Body)
+			if err != nil {
+				w.WriteHeader(http.StatusConflict)
+				json.NewEncoder(w).Encode(models.ErrorResponse{
+					Success: false,
+					Message: "Failed to append upload chunk",
+					Error:   err.Error(),
+				})
+				return
+			}
+
+			w.Header().Set("Upload-Offset", strconv.FormatInt(upload.Offset, 10))
+			w.WriteHeader(http.StatusNoContent)
+		})
+
+		r.Head("/uploads/{id}", func(w http.ResponseWriter, r *http.Request) {
+			id := chi.URLParam(r, "id")
+
+			upload, err := uploadStoreService.Get(id)
+			if err != nil {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+
+			w.Header().Set("Upload-Length", strconv.FormatInt(upload.Length, 10))
+			w.Header().Set("Upload-Offset", strconv.FormatInt(upload.Offset, 10))
+			w.WriteHeader(http.StatusOK)
+		})
+
+		// Finalizes a completed upload and kicks off OCR against it: images
+		// process synchronously, PDFs go through the same job queue as
+		// /process-pdf.
+		r.Post("/uploads/{id}/process", func(w http.ResponseWriter, r *http.Request) {
+			id := chi.URLParam(r, "id")
+
+			var req struct {
+				Type    string            `json:"type"`
+				Options models.OCROptions `json:"options"`
+				Backend string            `json:"backend"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				json.NewEncoder(w).Encode(models.ErrorResponse{
+					Success: false,
+					Message: "Invalid request body",
+					Error:   err.Error(),
+				})
+				return
+			}
+
+			_, finalPath, err := uploadStoreService.Finalize(id)
+			if err != nil {
+				w.WriteHeader(http.StatusConflict)
+				json.NewEncoder(w).Encode(models.ErrorResponse{
+					Success: false,
+					Message: "Failed to finalize upload",
+					Error:   err.Error(),
+				})
+				return
+			}
+
+			switch req.Type {
+			case "image":
+				result, err := ocrService.Execute(r.Context(), finalPath,
+					ExtractBlocks.WithOCROptions(req.Options),
+					ExtractBlocks.WithBackend(req.Backend),
+				)
+				if err != nil {
+					w.WriteHeader(http.StatusInternalServerError)
+					json.NewEncoder(w).Encode(models.ErrorResponse{
+						Success: false,
+						Message: "OCR processing failed",
+						Error:   err.Error(),
+					})
+					return
+				}
+
+				if requestID, err := storageService.CreateRequest(r.Context(), id, models.RequestTypeImage, 0, 1); err == nil {
+					result.RequestID = requestID
+				}
+
+				w.Header().Set("Content-Type", "application/json")
+				json.NewEncoder(w).Encode(models.ProcessImageResponse{
+					Success: true,
+					Message: "Image processed successfully",
+					Data:    result,
+				})
+
+			case "pdf":
+				// The job queue removes the path it's handed once it's done
+				// with it; hand it a private copy so the content-addressed
+				// original stays in place for any other upload sharing it.
+				jobPath, err := copyForProcessing(finalPath)
+				if err != nil {
+					w.WriteHeader(http.StatusInternalServerError)
+					json.NewEncoder(w).Encode(models.ErrorResponse{
+						Success: false,
+						Message: "Failed to stage upload for processing",
+						Error:   err.Error(),
+					})
+					return
+				}
+
+				job, err := jobQueueService.Enqueue(jobPath, req.Backend)
+				if err != nil {
+					os.Remove(jobPath)
+					w.WriteHeader(http.StatusServiceUnavailable)
+					json.NewEncoder(w).Encode(models.ErrorResponse{
+						Success: false,
+						Message: "Failed to queue PDF for processing",
+						Error:   err.Error(),
+					})
+					return
+				}
+
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusAccepted)
+				json.NewEncoder(w).Encode(models.APIResponse{
+					Success: true,
+					Message: "PDF queued for processing",
+					Data:    job,
+				})
+
+			default:
+				w.WriteHeader(http.StatusBadRequest)
+				json.NewEncoder(w).Encode(models.ErrorResponse{
+					Success: false,
+					Message: "Unknown upload type",
+					Error:   fmt.Sprintf("type must be \"image\" or \"pdf\", got %q", req.Type),
+				})
+			}
+		})
+
 		// Analysis endpoint
 		r.Post("/analyze", func(w http.ResponseWriter, r *http.Request) {
 			var req struct {
@@ -296,9 +758,66 @@ func main() {
 			})
 		})
 
+		// Streamed analysis endpoint: same request as /analyze, but flushes
+		// each delta to the client as it arrives over SSE instead of
+		// blocking for the full completion - useful for big documents,
+		// where /analyze can take up to its 60s HTTP timeout.
+		r.Post("/analyze/stream", func(w http.ResponseWriter, r *http.Request) {
+			var req struct {
+				Text   string `json:"text"`
+				Prompt string `json:"prompt"`
+			}
+
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				json.NewEncoder(w).Encode(models.ErrorResponse{
+					Success: false,
+					Message: "Invalid request body",
+					Error:   err.Error(),
+				})
+				return
+			}
+
+			chunks, err := llmClient.AnalyzeTextStream(r.Context(), req.Text, req.Prompt, 0.1)
+			if err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				json.NewEncoder(w).Encode(models.ErrorResponse{
+					Success: false,
+					Message: "LLM analysis failed",
+					Error:   err.Error(),
+				})
+				return
+			}
+
+			flusher, ok := w.(http.Flusher)
+			if !ok {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			w.Header().Set("Content-Type", "text/event-stream")
+			w.Header().Set("Cache-Control", "no-cache")
+			w.Header().Set("Connection", "keep-alive")
+			w.WriteHeader(http.StatusOK)
+			flusher.Flush()
+
+			for chunk := range chunks {
+				if chunk.Err != nil {
+					data, _ := json.Marshal(map[string]string{"error": chunk.Err.Error()})
+					fmt.Fprintf(w, "event: error\ndata: %s\n\n", data)
+					flusher.Flush()
+					return
+				}
+
+				data, _ := json.Marshal(map[string]string{"delta": chunk.Delta, "finish_reason": chunk.FinishReason})
+				fmt.Fprintf(w, "data: %s\n\n", data)
+				flusher.Flush()
+			}
+		})
+
 		// Request management
 		r.Get("/requests", func(w http.ResponseWriter, r *http.Request) {
-			requests, err := storageService.ListRequests()
+			requests, err := storageService.ListRequests(r.Context())
 			if err != nil {
 				w.WriteHeader(http.StatusInternalServerError)
 				json.NewEncoder(w).Encode(models.ErrorResponse{
@@ -320,7 +839,7 @@ func main() {
 		r.Get("/requests/{id}", func(w http.ResponseWriter, r *http.Request) {
 			id := chi.URLParam(r, "id")
 
-			metadata, err := storageService.GetMetadata(id)
+			metadata, err := storageService.GetMetadata(r.Context(), id)
 			if err != nil {
 				w.WriteHeader(http.StatusNotFound)
 				json.NewEncoder(w).Encode(models.ErrorResponse{
@@ -339,6 +858,157 @@ func main() {
 			})
 		})
 
+		// Polls a request's pending/processing/completed/failed status and
+		// per-page progress, for callers driving the Redis-backed job queue
+		// (internal/jobs) instead of the SSE-based /jobs/{id}/events stream.
+		r.Get("/requests/{id}/status", func(w http.ResponseWriter, r *http.Request) {
+			id := chi.URLParam(r, "id")
+
+			metadata, err := storageService.GetMetadata(r.Context(), id)
+			if err != nil {
+				w.WriteHeader(http.StatusNotFound)
+				json.NewEncoder(w).Encode(models.ErrorResponse{
+					Success: false,
+					Message: "Request not found",
+					Error:   err.Error(),
+				})
+				return
+			}
+
+			pagesDone := 0
+			if pages, err := listPagesService.Execute(r.Context(), id); err == nil {
+				for _, page := range pages.Pages {
+					if page.BlockCount > 0 {
+						pagesDone++
+					}
+				}
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(models.APIResponse{
+				Success: true,
+				Message: "Request status retrieved",
+				Data: map[string]interface{}{
+					"request_id":    id,
+					"status":        metadata.Status,
+					"progress":      metadata.Progress,
+					"pages_done":    pagesDone,
+					"pages_total":   metadata.TotalPages,
+					"error_message": metadata.ErrorMessage,
+				},
+			})
+		})
+
+		// Re-queues an existing request for re-OCR (e.g. against a different
+		// backend) through the Redis-backed job queue. source_path must be
+		// reachable by whichever cmd/worker process picks up the task.
+		r.Post("/requests/{id}/rejudge", func(w http.ResponseWriter, r *http.Request) {
+			id := chi.URLParam(r, "id")
+
+			var req struct {
+				SourcePath string             `json:"source_path"`
+				FileType   models.RequestType `json:"file_type"`
+				Backend    string             `json:"backend"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				json.NewEncoder(w).Encode(models.ErrorResponse{
+					Success: false,
+					Message: "Invalid request body",
+					Error:   err.Error(),
+				})
+				return
+			}
+			if req.SourcePath == "" {
+				w.WriteHeader(http.StatusBadRequest)
+				json.NewEncoder(w).Encode(models.ErrorResponse{
+					Success: false,
+					Message: "source_path is required",
+				})
+				return
+			}
+
+			if _, err := storageService.GetMetadata(r.Context(), id); err != nil {
+				w.WriteHeader(http.StatusNotFound)
+				json.NewEncoder(w).Encode(models.ErrorResponse{
+					Success: false,
+					Message: "Request not found",
+					Error:   err.Error(),
+				})
+				return
+			}
+
+			info, err := jobsClient.EnqueueRejudge(r.Context(), jobs.RejudgePayload{
+				RequestID:  id,
+				SourcePath: req.SourcePath,
+				FileType:   req.FileType,
+				Backend:    req.Backend,
+			})
+			if err != nil {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				json.NewEncoder(w).Encode(models.ErrorResponse{
+					Success: false,
+					Message: "Failed to queue rejudge task",
+					Error:   err.Error(),
+				})
+				return
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusAccepted)
+			json.NewEncoder(w).Encode(models.APIResponse{
+				Success: true,
+				Message: "Rejudge queued",
+				Data:    map[string]interface{}{"task_id": info.ID},
+			})
+		})
+
+		// Applies a template's fields to an already-OCR'd request, resolving
+		// each TemplateField's blocks, coercing values, and validating them -
+		// the same work as POST /templates/{id}/apply, mounted under the
+		// request instead of the template so a caller already on a request's
+		// detail page doesn't need the template ID in the body too.
+		r.Post("/requests/{id}/apply-template/{templateID}", func(w http.ResponseWriter, r *http.Request) {
+			requestID := chi.URLParam(r, "id")
+			templateID := chi.URLParam(r, "templateID")
+
+			var body struct {
+				PageNumber *int `json:"page_number,omitempty"`
+			}
+			if r.ContentLength != 0 {
+				if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+					w.WriteHeader(http.StatusBadRequest)
+					json.NewEncoder(w).Encode(models.ErrorResponse{
+						Success: false,
+						Message: "Invalid request body",
+						Error:   err.Error(),
+					})
+					return
+				}
+			}
+
+			result, err := applyTemplateService.Execute(r.Context(), templateID, models.TemplateApplyRequest{
+				RequestID:  requestID,
+				PageNumber: body.PageNumber,
+			})
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				json.NewEncoder(w).Encode(models.ErrorResponse{
+					Success: false,
+					Message: "Failed to apply template",
+					Error:   err.Error(),
+				})
+				return
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(models.APIResponse{
+				Success: true,
+				Message: "Template applied",
+				Data:    result,
+			})
+		})
+
 		// Block endpoints
 		r.Get("/blocks/{request_id}/{block_id}", func(w http.ResponseWriter, r *http.Request) {
 			requestID := chi.URLParam(r, "request_id")
@@ -454,6 +1124,9 @@ func main() {
 			})
 		})
 
+		// Streams the PNG crop CropBlocks generated for a single block
+		r.Get("/blocks/{block_id}/crop", cropblock.Handle(getBlockCropService))
+
 		// Page endpoints
 		r.Get("/pages/{request_id}/{page_number}", func(w http.ResponseWriter, r *http.Request) {
 			requestID := chi.URLParam(r, "request_id")
@@ -637,8 +1310,264 @@ func main() {
 				Data:    nil,
 			})
 		})
+
+		r.Post("/templates/{id}/apply", func(w http.ResponseWriter, r *http.Request) {
+			id := chi.URLParam(r, "id")
+
+			var req models.TemplateApplyRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				json.NewEncoder(w).Encode(models.ErrorResponse{
+					Success: false,
+					Message: "Invalid request body",
+					Error:   err.Error(),
+				})
+				return
+			}
+
+			result, err := applyTemplateService.Execute(r.Context(), id, req)
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				json.NewEncoder(w).Encode(models.ErrorResponse{
+					Success: false,
+					Message: "Failed to apply template",
+					Error:   err.Error(),
+				})
+				return
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(models.APIResponse{
+				Success: true,
+				Message: "Template applied",
+				Data:    result,
+			})
+		})
+
+		r.Post("/templates/learn", func(w http.ResponseWriter, r *http.Request) {
+			var req models.TemplateLearnRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				json.NewEncoder(w).Encode(models.ErrorResponse{
+					Success: false,
+					Message: "Invalid request body",
+					Error:   err.Error(),
+				})
+				return
+			}
+
+			template, err := learnTemplateService.Execute(r.Context(), req)
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				json.NewEncoder(w).Encode(models.ErrorResponse{
+					Success: false,
+					Message: "Failed to learn template",
+					Error:   err.Error(),
+				})
+				return
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(models.APIResponse{
+				Success: true,
+				Message: "Template learned",
+				Data:    template,
+			})
+		})
+
+		// Search endpoint (full-text search over the SQLite OCR index)
+		r.Get("/search", searchcontroller.Handle(searchBlocksService))
+
+		// OCR-indexed requests (distinct from /requests, which is file-backed)
+		r.Get("/ocr/requests", func(w http.ResponseWriter, r *http.Request) {
+			requests, err := listOCRRequestsService.Execute(r.Context())
+			if err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				json.NewEncoder(w).Encode(models.ErrorResponse{
+					Success: false,
+					Message: "Failed to list OCR requests",
+					Error:   err.Error(),
+				})
+				return
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(models.APIResponse{
+				Success: true,
+				Message: "OCR requests listed",
+				Data:    requests,
+			})
+		})
+	})
+
+	// /api/jobs/{id}/events streams a job's progress over SSE for as long as
+	// the job runs, so it's registered on the top-level router rather than
+	// inside the /api group above and its 60s timeout - the job itself keeps
+	// running on its own worker goroutine regardless of how long a caller
+	// stays subscribed to this stream.
+	r.Get("/api/jobs/{id}/events", func(w http.ResponseWriter, r *http.Request) {
+		id := chi.URLParam(r, "id")
+
+		events, unsubscribe, err := jobQueueService.Subscribe(id)
+		if err != nil {
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(models.ErrorResponse{
+				Success: false,
+				Message: "Job not found",
+				Error:   err.Error(),
+			})
+			return
+		}
+		defer unsubscribe()
+
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Connection", "keep-alive")
+		w.WriteHeader(http.StatusOK)
+		flusher.Flush()
+
+		for {
+			select {
+			case event, ok := <-events:
+				if !ok {
+					return
+				}
+				data, err := json.Marshal(event)
+				if err != nil {
+					continue
+				}
+				fmt.Fprintf(w, "data: %s\n\n", data)
+				flusher.Flush()
+			case <-r.Context().Done():
+				return
+			}
+		}
+	})
+
+	// /api/requests/{id}/correct and /extract are LLM-driven and can run well
+	// past 60s on a document with many blocks/pages, so they're registered on
+	// the top-level router rather than inside the /api group's timeout. Each
+	// runs the actual service call on a background context in its own
+	// goroutine and relays its progress events to this response as they
+	// arrive - a slow or disconnected client only drops the SSE relay, never
+	// the underlying correction/extraction work.
+
+	// correctBlocksOutcome carries the final result of a background
+	// correctBlocksService.Execute call back to the handler that started it,
+	// once every progress event already queued has been relayed.
+	type correctBlocksOutcome struct {
+		result *models.CorrectBlocksResult
+		err    error
+	}
+
+	// LLM-assisted OCR post-correction, streamed over SSE as each block
+	// finishes. ?dry_run=true computes the diff without saving it.
+	r.Post("/api/requests/{id}/correct", func(w http.ResponseWriter, r *http.Request) {
+		requestID := chi.URLParam(r, "id")
+		dryRun := r.URL.Query().Get("dry_run") == "true"
+		maxTokens, _ := strconv.Atoi(r.URL.Query().Get("max_tokens_per_block"))
+
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Connection", "keep-alive")
+		w.WriteHeader(http.StatusOK)
+		flusher.Flush()
+
+		events := make(chan CorrectBlocks.ProgressEvent, 16)
+		done := make(chan correctBlocksOutcome, 1)
+		go func() {
+			result, err := correctBlocksService.Execute(context.Background(), requestID, CorrectBlocks.Options{
+				DryRun:            dryRun,
+				MaxTokensPerBlock: maxTokens,
+				Progress: func(event CorrectBlocks.ProgressEvent) {
+					events <- event
+				},
+			})
+			close(events)
+			done <- correctBlocksOutcome{result: result, err: err}
+		}()
+
+		for event := range events {
+			writeSSE(w, flusher, event)
+		}
+		outcome := <-done
+		if outcome.err != nil {
+			writeSSE(w, flusher, models.ErrorResponse{Success: false, Message: "Correction failed", Error: outcome.err.Error()})
+			return
+		}
+		writeSSE(w, flusher, models.APIResponse{Success: true, Message: "Correction complete", Data: outcome.result})
 	})
-	
+
+	// extractOutcome is correctBlocksOutcome's counterpart for extractStructuredService.Execute.
+	type extractOutcome struct {
+		result *models.ExtractResult
+		err    error
+	}
+
+	// LLM-assisted structured field extraction against a caller-supplied
+	// JSON Schema, streamed over SSE as each page finishes.
+	r.Post("/api/requests/{id}/extract", func(w http.ResponseWriter, r *http.Request) {
+		requestID := chi.URLParam(r, "id")
+		maxTokens, _ := strconv.Atoi(r.URL.Query().Get("max_tokens_per_page"))
+
+		var schema map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&schema); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(models.ErrorResponse{
+				Success: false,
+				Message: "Invalid JSON Schema body",
+				Error:   err.Error(),
+			})
+			return
+		}
+
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Connection", "keep-alive")
+		w.WriteHeader(http.StatusOK)
+		flusher.Flush()
+
+		events := make(chan ExtractStructured.ProgressEvent, 16)
+		done := make(chan extractOutcome, 1)
+		go func() {
+			result, err := extractStructuredService.Execute(context.Background(), requestID, schema, ExtractStructured.Options{
+				MaxTokensPerPage: maxTokens,
+				Progress: func(event ExtractStructured.ProgressEvent) {
+					events <- event
+				},
+			})
+			close(events)
+			done <- extractOutcome{result: result, err: err}
+		}()
+
+		for event := range events {
+			writeSSE(w, flusher, event)
+		}
+		outcome := <-done
+		if outcome.err != nil {
+			writeSSE(w, flusher, models.ErrorResponse{Success: false, Message: "Extraction failed", Error: outcome.err.Error()})
+			return
+		}
+		writeSSE(w, flusher, models.APIResponse{Success: true, Message: "Extraction complete", Data: outcome.result})
+	})
+
 	// Create server
 	srv := &http.Server{
 		Addr:         cfg.GetAddress(),
@@ -647,7 +1576,7 @@ func main() {
 		WriteTimeout: 15 * time.Second,
 		IdleTimeout:  60 * time.Second,
 	}
-	
+
 	// Start server in goroutine
 	go func() {
 		log.Printf("Starting server on %s", cfg.GetAddress())
@@ -655,21 +1584,57 @@ func main() {
 			log.Fatalf("Server failed to start: %v", err)
 		}
 	}()
-	
+
 	// Wait for interrupt signal
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
-	
+
 	log.Println("Shutting down server...")
-	
+
 	// Graceful shutdown
 	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer shutdownCancel()
-	
+
 	if err := srv.Shutdown(shutdownCtx); err != nil {
 		log.Fatalf("Server forced to shutdown: %v", err)
 	}
-	
+
 	log.Println("Server stopped")
 }
+
+// copyForProcessing makes a private copy of a content-addressed upload so a
+// subsystem that takes ownership of (and deletes) its input path, like the
+// PDF job queue, never touches the shared original.
+func copyForProcessing(path string) (string, error) {
+	src, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to open upload: %w", err)
+	}
+	defer src.Close()
+
+	dst, err := os.CreateTemp("", "upload-job-*.pdf")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temp file: %w", err)
+	}
+	defer dst.Close()
+
+	if _, err := io.Copy(dst, src); err != nil {
+		os.Remove(dst.Name())
+		return "", fmt.Errorf("failed to copy upload: %w", err)
+	}
+	return dst.Name(), nil
+}
+
+// writeSSE marshals v as JSON and writes it as a single SSE "data:" frame,
+// flushing immediately so long-running handlers (correction, extraction)
+// can stream progress instead of buffering the whole response.
+func writeSSE(w http.ResponseWriter, flusher http.Flusher, v interface{}) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		log.Printf("Warning: failed to marshal SSE event: %v", err)
+		return
+	}
+	fmt.Fprintf(w, "data: %s\n\n", data)
+	flusher.Flush()
+}
@@ -0,0 +1,82 @@
+// Command worker consumes the Redis-backed task queue in internal/jobs -
+// ProcessPDF, ProcessImage, and Rejudge tasks enqueued by cmd/server (or any
+// other producer) - and updates RequestMetadata.Status as each one
+// completes. Any number of worker processes can run against the same Redis
+// instance and object storage backend to scale OCR throughput horizontally.
+package main
+
+import (
+	"context"
+	"log"
+	"path/filepath"
+
+	"github.com/hibiken/asynq"
+	"github.com/plobin/genkitgo/internal/config"
+	"github.com/plobin/genkitgo/internal/jobs"
+	"github.com/plobin/genkitgo/internal/logger"
+	"github.com/plobin/genkitgo/internal/observability"
+	"github.com/plobin/genkitgo/internal/services/File/Storage"
+	"github.com/plobin/genkitgo/internal/services/OCR/ExtractBlocks"
+	"github.com/plobin/genkitgo/internal/services/OCR/Store"
+	"github.com/plobin/genkitgo/internal/services/PDF/ProcessPDF"
+	"github.com/plobin/genkitgo/internal/services/Visualization/CropBlocks"
+	"github.com/plobin/genkitgo/internal/storage"
+)
+
+func main() {
+	cfg := config.Load()
+	logger.Init(cfg.LogFormat)
+
+	otelShutdown, err := observability.Init(context.Background())
+	if err != nil {
+		log.Fatalf("Failed to initialize observability: %v", err)
+	}
+	defer otelShutdown(context.Background())
+
+	objectStore, err := storage.New(context.Background(), storage.Config{
+		Backend:    cfg.StorageBackend,
+		LocalDir:   cfg.OutputDir,
+		Endpoint:   cfg.StorageEndpoint,
+		Bucket:     cfg.StorageBucket,
+		AccessKey:  cfg.StorageAccessKey,
+		SecretKey:  cfg.StorageSecretKey,
+		UseSSL:     cfg.StorageUseSSL,
+		CacheReads: cfg.StorageCacheReads,
+	})
+	if err != nil {
+		log.Fatalf("Failed to initialize storage backend: %v", err)
+	}
+	storageService := Storage.NewService(objectStore)
+
+	store, err := Store.NewService(filepath.Join(cfg.OutputDir, "ocr_index.db"))
+	if err != nil {
+		log.Fatalf("Failed to open OCR store: %v", err)
+	}
+	defer store.Close()
+
+	ocrService := ExtractBlocks.NewServiceWithBackend(cfg.OCRLanguage, cfg.OCREngine)
+	cropService := CropBlocks.NewService(cfg.OutputDir)
+	pdfService := ProcessPDF.NewServiceWithBackend(cfg.OCRLanguage, 150.0, cfg.OCREngine, store, cropService)
+
+	handler := jobs.NewHandler(ocrService, pdfService, storageService)
+
+	mux := asynq.NewServeMux()
+	mux.HandleFunc(jobs.TypeProcessPDF, handler.HandleProcessPDFTask)
+	mux.HandleFunc(jobs.TypeProcessImage, handler.HandleProcessImageTask)
+	mux.HandleFunc(jobs.TypeRejudge, handler.HandleRejudgeTask)
+
+	concurrency := cfg.MaxWorkers
+	if concurrency <= 0 {
+		concurrency = 4
+	}
+
+	srv := asynq.NewServer(
+		asynq.RedisClientOpt{Addr: cfg.RedisAddr},
+		asynq.Config{Concurrency: concurrency},
+	)
+
+	log.Printf("Starting OCR worker (redis: %s, concurrency: %d)", cfg.RedisAddr, concurrency)
+	if err := srv.Run(mux); err != nil {
+		log.Fatalf("Worker failed: %v", err)
+	}
+}
@@ -2,7 +2,7 @@ package config
 
 import (
 	"fmt"
-	"log"
+	"log/slog"
 	"os"
 	"strconv"
 
@@ -13,30 +13,60 @@ type Config struct {
 	// Server configuration
 	ServerPort string
 	ServerHost string
-	
+
+	// Logging configuration: "json" (default, suited to log aggregation)
+	// or "text" for human-readable local development output. See
+	// internal/logger.
+	LogFormat string
+
 	// OCR configuration
-	OCREngine   string // "surya" (ML-based layout detection + OCR)
+	OCREngine   string // registered OCR backend/mode: "surya", "tesseract", "auto", "ensemble", or a cloud backend name
+	OCRBackend  string // deprecated alias for OCREngine, kept for existing OCR_BACKEND deployments
 	OCRLanguage string // "kor", "eng", etc.
 	UseGPU      bool
-	
+
 	// PDF configuration
 	PDFDpi int
-	
+
 	// LLM configuration
 	LLMBaseURL string
 	LLMAPIKey  string
 	LLMModel   string
-	
+
 	// Storage configuration
-	OutputDir    string
-	CacheDir     string
-	TemplateDir  string
-	DemoDir      string
-	
+	OutputDir   string
+	CacheDir    string
+	TemplateDir string
+	DemoDir     string
+
+	// Object storage backend for request/page/template artifacts - "local"
+	// (default, OutputDir on disk) or "s3" for an S3-compatible bucket
+	// (MinIO, AWS S3, ...) so multiple stateless API instances can share
+	// the same data. See internal/storage.
+	StorageBackend    string
+	StorageEndpoint   string
+	StorageBucket     string
+	StorageAccessKey  string
+	StorageSecretKey  string
+	StorageUseSSL     bool
+	StorageCacheReads bool
+
+	// Redis address (host:port) backing the distributed job queue in
+	// internal/jobs, consumed by cmd/worker. Separate from MaxWorkers,
+	// which also sets that worker's per-process concurrency.
+	RedisAddr string
+
+	// GRPCAddr, when set, is the address cmd/server would listen on for the
+	// gRPC OCR service (internal/grpc/ocr). Empty by default: that service
+	// has no generated stubs or server implementation yet (see
+	// internal/grpc/ocr/generate.go), so cmd/server refuses to start if this
+	// is set rather than silently ignoring it.
+	GRPCAddr string
+
 	// Performance configuration
 	MaxWorkers        int
 	EnableCompression bool
-	
+
 	// Debugging
 	Debug bool
 }
@@ -44,46 +74,62 @@ type Config struct {
 func Load() *Config {
 	// Load .env file
 	if err := godotenv.Load(); err != nil {
-		log.Println("No .env file found, using environment variables")
+		slog.Warn("no .env file found, using environment variables")
 	}
-	
+
 	cfg := &Config{
 		// Server
 		ServerPort: getEnv("SERVER_PORT", "6003"),
 		ServerHost: getEnv("SERVER_HOST", "0.0.0.0"),
-		
+
+		// Logging
+		LogFormat: getEnv("LOG_FORMAT", "json"),
+
 		// OCR
-		OCREngine:   getEnv("OCR_ENGINE", "surya"),
+		OCREngine:   getEnv("OCR_ENGINE", getEnv("OCR_BACKEND", "surya")),
+		OCRBackend:  getEnv("OCR_BACKEND", "surya"),
 		OCRLanguage: getEnv("OCR_LANGUAGE", "kor+eng"),
 		UseGPU:      getEnvBool("USE_GPU", false),
-		
+
 		// PDF
 		PDFDpi: getEnvInt("PDF_DPI", 300),
-		
+
 		// LLM
 		LLMBaseURL: getEnv("LLM_BASE_URL", "https://llm.gupsa.net/v1"),
 		LLMAPIKey:  getEnv("LLM_API_KEY", ""),
 		LLMModel:   getEnv("LLM_MODEL", "boto"),
-		
+
 		// Storage
 		OutputDir:   getEnv("OUTPUT_DIR", "output"),
 		CacheDir:    getEnv("CACHE_DIR", "cache"),
 		TemplateDir: getEnv("TEMPLATE_DIR", "templates"),
 		DemoDir:     getEnv("DEMO_DIR", "demo"),
-		
+
+		StorageBackend:    getEnv("STORAGE_BACKEND", "local"),
+		StorageEndpoint:   getEnv("STORAGE_ENDPOINT", ""),
+		StorageBucket:     getEnv("STORAGE_BUCKET", "genkitgo-ocr"),
+		StorageAccessKey:  getEnv("STORAGE_ACCESS_KEY", ""),
+		StorageSecretKey:  getEnv("STORAGE_SECRET_KEY", ""),
+		StorageUseSSL:     getEnvBool("STORAGE_USE_SSL", true),
+		StorageCacheReads: getEnvBool("STORAGE_CACHE_READS", false),
+
+		RedisAddr: getEnv("REDIS_ADDR", "localhost:6379"),
+
+		GRPCAddr: getEnv("GRPC_ADDR", ""),
+
 		// Performance
 		MaxWorkers:        getEnvInt("MAX_WORKERS", 4),
 		EnableCompression: getEnvBool("ENABLE_COMPRESSION", true),
-		
+
 		// Debugging
 		Debug: getEnvBool("DEBUG", false),
 	}
-	
+
 	// Validate required fields (LLM API Key는 선택사항으로 변경)
 	if cfg.LLMAPIKey == "" {
-		log.Println("Warning: LLM_API_KEY not set, LLM features may not work")
+		slog.Warn("LLM_API_KEY not set, LLM features may not work")
 	}
-	
+
 	return cfg
 }
 
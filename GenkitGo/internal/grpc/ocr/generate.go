@@ -0,0 +1,16 @@
+// Package ocr is the IDL-only milestone of a planned gRPC OCR service meant
+// to expose thin adapters around the same *Service.Execute methods
+// internal/http/controllers/OCR already calls. It is NOT wired up: there is
+// no server.go, no generated stubs, and nothing registered in cmd/server.
+// cmd/server refuses to start if config.GRPCAddr is set, specifically so
+// this incompleteness can't go unnoticed in a deployment.
+//
+// protoc / protoc-gen-go / protoc-gen-go-grpc are not available in every
+// build environment this module is checked out into, so generated code
+// (ocr.pb.go, ocr_grpc.pb.go) is intentionally not checked in. Finishing
+// this service means: run `go generate ./...` with protoc on PATH to
+// produce it, implement a server.go's OcrServiceServer against the
+// generated types, and register it in cmd/server behind GRPCAddr.
+package ocr
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative ocr.proto
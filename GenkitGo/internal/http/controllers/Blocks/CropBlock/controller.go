@@ -0,0 +1,36 @@
+package cropblock
+
+import (
+	"net/http"
+
+	errorresponse "github.com/plobin/genkitgo/internal/http/common/ErrorResponse"
+	"github.com/plobin/genkitgo/internal/services/Visualization/GetBlockCrop"
+)
+
+// Handle - 블록 크롭 이미지 스트리밍 핸들러 (1파일 1메서드 원칙)
+// Route: GET /api/blocks/{block_id}/crop
+// 역할: Request 수신 → Service 호출 → PNG 스트리밍
+func Handle(service *GetBlockCrop.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		req, err := ValidateRequest(r)
+		if err != nil {
+			errorresponse.Write(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		crop, err := service.Execute(r.Context(), req.BlockID)
+		if err != nil {
+			errorresponse.Write(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		w.Header().Set("ETag", crop.ETag)
+		if match := r.Header.Get("If-None-Match"); match != "" && match == crop.ETag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		w.Header().Set("Content-Type", crop.ContentType)
+		w.Write(crop.Data)
+	}
+}
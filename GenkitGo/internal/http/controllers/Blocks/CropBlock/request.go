@@ -0,0 +1,25 @@
+package cropblock
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// CropBlockRequest - 블록 크롭 이미지 요청 구조체
+type CropBlockRequest struct {
+	BlockID int
+}
+
+// ValidateRequest - 입력 검증 (1파일 1메서드 원칙)
+func ValidateRequest(r *http.Request) (*CropBlockRequest, error) {
+	blockIDStr := chi.URLParam(r, "block_id")
+	blockID, err := strconv.Atoi(blockIDStr)
+	if err != nil {
+		return nil, errors.New("block_id는 정수여야 합니다")
+	}
+
+	return &CropBlockRequest{BlockID: blockID}, nil
+}
@@ -16,6 +16,8 @@ type ProcessImageRequest struct {
 
 // ValidateRequest - 입력 검증 (1파일 1메서드 원칙)
 // 역할: HTTP Request Body → 구조체 변환 + 유효성 검증
+// 기본값은 더 이상 여기서 설정하지 않는다 - ExtractBlocksOptions.Apply가
+// NewService와 공유하는 단일 기본값 집합으로 채운다.
 func ValidateRequest(r *http.Request) (*ProcessImageRequest, error) {
 	var req ProcessImageRequest
 
@@ -29,14 +31,5 @@ func ValidateRequest(r *http.Request) (*ProcessImageRequest, error) {
 		return nil, errors.New("image_path는 필수입니다")
 	}
 
-	// 기본값 설정
-	if req.ConfidenceThreshold == 0 {
-		req.ConfidenceThreshold = 0.5
-	}
-
-	if req.MergeThreshold == 0 {
-		req.MergeThreshold = 30
-	}
-
 	return &req, nil
 }
@@ -3,17 +3,39 @@ package processpdf
 import (
 	"net/http"
 
+	"github.com/google/uuid"
+	errorresponse "github.com/plobin/genkitgo/internal/http/common/ErrorResponse"
 	successresponse "github.com/plobin/genkitgo/internal/http/common/SuccessResponse"
+	"github.com/plobin/genkitgo/internal/services/PDF/ProcessPDF"
 )
 
 // Handle - PDF OCR 처리 핸들러 (1파일 1메서드 원칙)
 // Route: POST /api/ocr/process-pdf
 // 역할: Request 수신 → Service 호출 → Response 반환
-func Handle(w http.ResponseWriter, r *http.Request) {
-	// TODO: PDF 처리 로직 구현
-	// FastAPI의 api/endpoints/process_pdf.py 포팅 필요
+func Handle(service *ProcessPDF.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// 1. Request 검증
+		req, err := ValidateRequest(r)
+		if err != nil {
+			errorresponse.Write(w, http.StatusBadRequest, err.Error())
+			return
+		}
 
-	successresponse.Write(w, map[string]string{
-		"message": "PDF 처리 엔드포인트 (구현 예정)",
-	})
+		// 2. Service 호출 (비즈니스 로직 위임)
+		requestID := uuid.New().String()
+		result, err := service.Execute(r.Context(), req.PDFPath, requestID,
+			ProcessPDF.WithConfidenceThreshold(req.ConfidenceThreshold),
+			ProcessPDF.WithMergeBlocks(req.MergeBlocks),
+			ProcessPDF.WithMergeThreshold(req.MergeThreshold),
+			ProcessPDF.WithLanguage(req.Language),
+			ProcessPDF.WithDPI(req.DPI),
+		)
+		if err != nil {
+			errorresponse.Write(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		// 3. Response 반환
+		successresponse.Write(w, result)
+	}
 }
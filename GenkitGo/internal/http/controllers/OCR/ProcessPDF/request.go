@@ -11,9 +11,14 @@ type ProcessPDFRequest struct {
 	PDFPath             string  `json:"pdf_path" validate:"required"`
 	ConfidenceThreshold float64 `json:"confidence_threshold"`
 	MergeBlocks         bool    `json:"merge_blocks"`
+	MergeThreshold      int     `json:"merge_threshold"`
+	Language            string  `json:"language"`
+	DPI                 float64 `json:"dpi"`
 }
 
 // ValidateRequest - 입력 검증 (1파일 1메서드 원칙)
+// 기본값은 더 이상 여기서 설정하지 않는다 - ProcessPDFOptions.Apply가
+// 서비스와 공유하는 단일 기본값 집합으로 채운다.
 func ValidateRequest(r *http.Request) (*ProcessPDFRequest, error) {
 	var req ProcessPDFRequest
 
@@ -25,9 +30,5 @@ func ValidateRequest(r *http.Request) (*ProcessPDFRequest, error) {
 		return nil, errors.New("pdf_path는 필수입니다")
 	}
 
-	if req.ConfidenceThreshold == 0 {
-		req.ConfidenceThreshold = 0.5
-	}
-
 	return &req, nil
 }
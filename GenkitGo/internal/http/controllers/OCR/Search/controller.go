@@ -0,0 +1,30 @@
+package search
+
+import (
+	"net/http"
+
+	errorresponse "github.com/plobin/genkitgo/internal/http/common/ErrorResponse"
+	successresponse "github.com/plobin/genkitgo/internal/http/common/SuccessResponse"
+	"github.com/plobin/genkitgo/internal/services/OCR/SearchBlocks"
+)
+
+// Handle - 블록 전문 검색 핸들러 (1파일 1메서드 원칙)
+// Route: GET /api/search?q=...&confidence_min=...&block_type=...&request_id=...
+// 역할: Request 수신 → Service 호출 → Response 반환
+func Handle(service *SearchBlocks.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		req, err := ValidateRequest(r)
+		if err != nil {
+			errorresponse.Write(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		hits, err := service.Execute(r.Context(), req.Query, req.Filter, req.Limit)
+		if err != nil {
+			errorresponse.Write(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		successresponse.Write(w, hits)
+	}
+}
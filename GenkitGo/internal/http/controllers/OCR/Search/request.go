@@ -0,0 +1,52 @@
+package search
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/plobin/genkitgo/internal/services/OCR/Store"
+)
+
+// SearchRequest - 블록 전문 검색 요청 구조체
+type SearchRequest struct {
+	Query  string
+	Filter Store.SearchFilter
+	Limit  int
+}
+
+// ValidateRequest - 쿼리 파라미터 검증 (1파일 1메서드 원칙)
+// 역할: HTTP Query String → 구조체 변환 + 유효성 검증
+func ValidateRequest(r *http.Request) (*SearchRequest, error) {
+	query := r.URL.Query().Get("q")
+	if query == "" {
+		return nil, errors.New("q는 필수입니다")
+	}
+
+	req := &SearchRequest{
+		Query: query,
+		Filter: Store.SearchFilter{
+			RequestID: r.URL.Query().Get("request_id"),
+			BlockType: r.URL.Query().Get("block_type"),
+		},
+		Limit: 20,
+	}
+
+	if v := r.URL.Query().Get("confidence_min"); v != "" {
+		minConf, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return nil, errors.New("confidence_min은 숫자여야 합니다")
+		}
+		req.Filter.MinConfidence = minConf
+	}
+
+	if v := r.URL.Query().Get("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, errors.New("limit은 정수여야 합니다")
+		}
+		req.Limit = limit
+	}
+
+	return req, nil
+}
@@ -0,0 +1,66 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hibiken/asynq"
+)
+
+// Client enqueues tasks onto the Redis-backed queue for cmd/worker to pick
+// up. Construct one with NewClient and let the owning process Close it on
+// shutdown.
+type Client struct {
+	asynqClient *asynq.Client
+}
+
+// NewClient connects to the Redis instance at redisAddr (host:port).
+func NewClient(redisAddr string) *Client {
+	return &Client{
+		asynqClient: asynq.NewClient(asynq.RedisClientOpt{Addr: redisAddr}),
+	}
+}
+
+// Close releases the underlying Redis connection.
+func (c *Client) Close() error {
+	return c.asynqClient.Close()
+}
+
+// EnqueueProcessPDF schedules a PDF for background OCR processing.
+func (c *Client) EnqueueProcessPDF(ctx context.Context, payload ProcessPDFPayload) (*asynq.TaskInfo, error) {
+	task, err := NewProcessPDFTask(payload)
+	if err != nil {
+		return nil, err
+	}
+	info, err := c.asynqClient.EnqueueContext(ctx, task)
+	if err != nil {
+		return nil, fmt.Errorf("failed to enqueue process-pdf task: %w", err)
+	}
+	return info, nil
+}
+
+// EnqueueProcessImage schedules an image for background OCR processing.
+func (c *Client) EnqueueProcessImage(ctx context.Context, payload ProcessImagePayload) (*asynq.TaskInfo, error) {
+	task, err := NewProcessImageTask(payload)
+	if err != nil {
+		return nil, err
+	}
+	info, err := c.asynqClient.EnqueueContext(ctx, task)
+	if err != nil {
+		return nil, fmt.Errorf("failed to enqueue process-image task: %w", err)
+	}
+	return info, nil
+}
+
+// EnqueueRejudge schedules an existing request for re-OCR.
+func (c *Client) EnqueueRejudge(ctx context.Context, payload RejudgePayload) (*asynq.TaskInfo, error) {
+	task, err := NewRejudgeTask(payload)
+	if err != nil {
+		return nil, err
+	}
+	info, err := c.asynqClient.EnqueueContext(ctx, task)
+	if err != nil {
+		return nil, fmt.Errorf("failed to enqueue rejudge task: %w", err)
+	}
+	return info, nil
+}
@@ -0,0 +1,206 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/plobin/genkitgo/internal/models"
+	"github.com/plobin/genkitgo/internal/services/File/Storage"
+	"github.com/plobin/genkitgo/internal/services/OCR/ExtractBlocks"
+	"github.com/plobin/genkitgo/internal/services/PDF/ProcessPDF"
+)
+
+// Handler performs the work behind each task type, reusing the same
+// services cmd/server wires up for its synchronous/in-process paths. It
+// drives RequestMetadata.Status through pending -> processing ->
+// completed/failed, the transition GET /api/requests/{id}/status reports.
+type Handler struct {
+	ocrService     *ExtractBlocks.Service
+	pdfService     *ProcessPDF.Service
+	storageService *Storage.Service
+}
+
+// NewHandler builds a Handler. Pass the same service instances cmd/server
+// and cmd/worker both construct from config.Config.
+func NewHandler(ocrService *ExtractBlocks.Service, pdfService *ProcessPDF.Service, storageService *Storage.Service) *Handler {
+	return &Handler{
+		ocrService:     ocrService,
+		pdfService:     pdfService,
+		storageService: storageService,
+	}
+}
+
+// HandleProcessPDFTask implements asynq.HandlerFunc for TypeProcessPDF.
+func (h *Handler) HandleProcessPDFTask(ctx context.Context, task *asynq.Task) (err error) {
+	var payload ProcessPDFPayload
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal process-pdf payload: %w", err)
+	}
+	// Keep the PDF for asynq's retries; remove it once the task succeeds or
+	// its last retry has run.
+	defer func() {
+		retried, _ := asynq.GetRetryCount(ctx)
+		maxRetry, _ := asynq.GetMaxRetry(ctx)
+		if err == nil || retried >= maxRetry {
+			os.Remove(payload.PDFPath)
+		}
+	}()
+
+	if err := h.markProcessing(ctx, payload.RequestID); err != nil {
+		log.Printf("jobs: failed to mark %s processing: %v", payload.RequestID, err)
+	}
+
+	result, err := h.pdfService.Execute(ctx, payload.PDFPath, payload.RequestID,
+		ProcessPDF.WithBackend(payload.Backend),
+		ProcessPDF.WithProgress(func(pageDone, pageTotal int) {
+			h.markProgress(ctx, payload.RequestID, pageDone, pageTotal)
+		}),
+	)
+	if err != nil {
+		h.markFailed(ctx, payload.RequestID, err)
+		return fmt.Errorf("process-pdf task failed for request %s: %w", payload.RequestID, err)
+	}
+
+	h.markCompleted(ctx, payload.RequestID, result.TotalPages)
+	return nil
+}
+
+// HandleProcessImageTask implements asynq.HandlerFunc for TypeProcessImage.
+func (h *Handler) HandleProcessImageTask(ctx context.Context, task *asynq.Task) (err error) {
+	var payload ProcessImagePayload
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal process-image payload: %w", err)
+	}
+	// Keep the image for asynq's retries; remove it once the task succeeds
+	// or its last retry has run.
+	defer func() {
+		retried, _ := asynq.GetRetryCount(ctx)
+		maxRetry, _ := asynq.GetMaxRetry(ctx)
+		if err == nil || retried >= maxRetry {
+			os.Remove(payload.ImagePath)
+		}
+	}()
+
+	if err := h.markProcessing(ctx, payload.RequestID); err != nil {
+		log.Printf("jobs: failed to mark %s processing: %v", payload.RequestID, err)
+	}
+
+	_, err = h.ocrService.Execute(ctx, payload.ImagePath,
+		ExtractBlocks.WithOCROptions(payload.Options),
+		ExtractBlocks.WithBackend(payload.Backend),
+	)
+	if err != nil {
+		h.markFailed(ctx, payload.RequestID, err)
+		return fmt.Errorf("process-image task failed for request %s: %w", payload.RequestID, err)
+	}
+
+	h.markCompleted(ctx, payload.RequestID, 1)
+	return nil
+}
+
+// HandleRejudgeTask implements asynq.HandlerFunc for TypeRejudge: it re-runs
+// the same pipeline as HandleProcessPDFTask/HandleProcessImageTask against
+// an existing request's original source file, overwriting its pages.
+func (h *Handler) HandleRejudgeTask(ctx context.Context, task *asynq.Task) error {
+	var payload RejudgePayload
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal rejudge payload: %w", err)
+	}
+
+	if err := h.markProcessing(ctx, payload.RequestID); err != nil {
+		log.Printf("jobs: failed to mark %s processing: %v", payload.RequestID, err)
+	}
+
+	var (
+		totalPages int
+		err        error
+	)
+	switch payload.FileType {
+	case models.RequestTypePDF:
+		var result *ProcessPDF.PDFResult
+		result, err = h.pdfService.Execute(ctx, payload.SourcePath, payload.RequestID,
+			ProcessPDF.WithBackend(payload.Backend),
+			ProcessPDF.WithProgress(func(pageDone, pageTotal int) {
+				h.markProgress(ctx, payload.RequestID, pageDone, pageTotal)
+			}),
+		)
+		if result != nil {
+			totalPages = result.TotalPages
+		}
+	case models.RequestTypeImage:
+		_, err = h.ocrService.Execute(ctx, payload.SourcePath, ExtractBlocks.WithBackend(payload.Backend))
+		totalPages = 1
+	default:
+		err = fmt.Errorf("unknown file type %q for rejudge", payload.FileType)
+	}
+
+	if err != nil {
+		h.markFailed(ctx, payload.RequestID, err)
+		return fmt.Errorf("rejudge task failed for request %s: %w", payload.RequestID, err)
+	}
+
+	h.markCompleted(ctx, payload.RequestID, totalPages)
+	return nil
+}
+
+func (h *Handler) markProcessing(ctx context.Context, requestID string) error {
+	metadata, err := h.storageService.GetMetadata(ctx, requestID)
+	if err != nil {
+		return err
+	}
+	metadata.Status = models.RequestStatusProcessing
+	metadata.Progress = 0
+	metadata.UpdatedAt = time.Now()
+	return h.storageService.SaveMetadata(ctx, requestID, metadata)
+}
+
+// markProgress updates Progress to the percentage of pages done so far,
+// called from ProcessPDF.WithProgress after each page finishes OCR.
+func (h *Handler) markProgress(ctx context.Context, requestID string, pageDone, pageTotal int) {
+	metadata, err := h.storageService.GetMetadata(ctx, requestID)
+	if err != nil {
+		log.Printf("jobs: failed to load metadata for %s: %v", requestID, err)
+		return
+	}
+	if pageTotal > 0 {
+		metadata.Progress = pageDone * 100 / pageTotal
+	}
+	metadata.UpdatedAt = time.Now()
+	if err := h.storageService.SaveMetadata(ctx, requestID, metadata); err != nil {
+		log.Printf("jobs: failed to save progress for %s: %v", requestID, err)
+	}
+}
+
+func (h *Handler) markCompleted(ctx context.Context, requestID string, totalPages int) {
+	metadata, err := h.storageService.GetMetadata(ctx, requestID)
+	if err != nil {
+		log.Printf("jobs: failed to load metadata for %s: %v", requestID, err)
+		return
+	}
+	metadata.Status = models.RequestStatusCompleted
+	metadata.TotalPages = totalPages
+	metadata.Progress = 100
+	metadata.UpdatedAt = time.Now()
+	if err := h.storageService.SaveMetadata(ctx, requestID, metadata); err != nil {
+		log.Printf("jobs: failed to save completed metadata for %s: %v", requestID, err)
+	}
+}
+
+func (h *Handler) markFailed(ctx context.Context, requestID string, taskErr error) {
+	metadata, err := h.storageService.GetMetadata(ctx, requestID)
+	if err != nil {
+		log.Printf("jobs: failed to load metadata for %s: %v", requestID, err)
+		return
+	}
+	metadata.Status = models.RequestStatusFailed
+	metadata.ErrorMessage = taskErr.Error()
+	metadata.UpdatedAt = time.Now()
+	if err := h.storageService.SaveMetadata(ctx, requestID, metadata); err != nil {
+		log.Printf("jobs: failed to save failed metadata for %s: %v", requestID, err)
+	}
+}
@@ -0,0 +1,83 @@
+// Package jobs is a Redis-backed task queue (hibiken/asynq) for
+// submit-and-poll OCR processing: a request is enqueued here, a request ID
+// comes back immediately, and the caller polls GET /api/requests/{id}/status
+// (backed by RequestMetadata.Status) for completion. It complements
+// internal/services/Job/Queue, which runs process-pdf jobs in-process with
+// SSE progress for a single API instance; this package lets that work be
+// picked up by any number of cmd/worker processes instead.
+package jobs
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hibiken/asynq"
+	"github.com/plobin/genkitgo/internal/models"
+)
+
+// Task type names routed by cmd/worker's asynq.ServeMux.
+const (
+	TypeProcessPDF   = "ocr:process_pdf"
+	TypeProcessImage = "ocr:process_image"
+	TypeRejudge      = "ocr:rejudge"
+)
+
+// defaultMaxRetry bounds asynq's built-in exponential backoff retry for
+// transient OCR/LLM failures (network blips, backend timeouts).
+const defaultMaxRetry = 3
+
+// ProcessPDFPayload processes a PDF already on disk at PDFPath into a new
+// request. The worker removes PDFPath once processing finishes, mirroring
+// Job/Queue's ownership convention.
+type ProcessPDFPayload struct {
+	RequestID string `json:"request_id"`
+	PDFPath   string `json:"pdf_path"`
+	Backend   string `json:"backend,omitempty"`
+}
+
+// ProcessImagePayload processes a single image already on disk at ImagePath
+// into a new request.
+type ProcessImagePayload struct {
+	RequestID string            `json:"request_id"`
+	ImagePath string            `json:"image_path"`
+	Backend   string            `json:"backend,omitempty"`
+	Options   models.OCROptions `json:"options"`
+}
+
+// RejudgePayload re-runs OCR for an existing request against its original
+// source file, overwriting that request's pages - e.g. to retry with a
+// different backend or OCR options. SourcePath must still be reachable by
+// whichever worker picks up the task.
+type RejudgePayload struct {
+	RequestID  string             `json:"request_id"`
+	SourcePath string             `json:"source_path"`
+	FileType   models.RequestType `json:"file_type"`
+	Backend    string             `json:"backend,omitempty"`
+}
+
+// NewProcessPDFTask builds an asynq.Task for TypeProcessPDF.
+func NewProcessPDFTask(payload ProcessPDFPayload) (*asynq.Task, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal process-pdf payload: %w", err)
+	}
+	return asynq.NewTask(TypeProcessPDF, data, asynq.MaxRetry(defaultMaxRetry)), nil
+}
+
+// NewProcessImageTask builds an asynq.Task for TypeProcessImage.
+func NewProcessImageTask(payload ProcessImagePayload) (*asynq.Task, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal process-image payload: %w", err)
+	}
+	return asynq.NewTask(TypeProcessImage, data, asynq.MaxRetry(defaultMaxRetry)), nil
+}
+
+// NewRejudgeTask builds an asynq.Task for TypeRejudge.
+func NewRejudgeTask(payload RejudgePayload) (*asynq.Task, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal rejudge payload: %w", err)
+	}
+	return asynq.NewTask(TypeRejudge, data, asynq.MaxRetry(defaultMaxRetry)), nil
+}
@@ -0,0 +1,257 @@
+// Package hierarchy builds the nested Section/HierarchyTree structure
+// models.BlockResult (and the OCRResult/PageResult types that share its
+// shape) declare but leave unpopulated. Given a flat, backend-ordered
+// []BlockInfo, it reconstructs reading order across columns and groups
+// blocks under whichever Title/Header opens them, so a caller can attach
+// the result to its own OCR result before persisting it.
+package hierarchy
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/plobin/genkitgo/internal/models"
+)
+
+// Options tunes BuildHierarchy's column detection. Kept as a struct (not a
+// bare threshold argument) so future knobs don't need a signature change.
+type Options struct {
+	// ColumnOverlapThreshold is the fraction of X-range overlap two blocks
+	// must exceed for reading order to treat them as the same column.
+	ColumnOverlapThreshold float64
+}
+
+// DefaultOptions is what a zero-value Options resolves to.
+var DefaultOptions = Options{ColumnOverlapThreshold: 0.5}
+
+func (o Options) withDefaults() Options {
+	if o.ColumnOverlapThreshold <= 0 {
+		o.ColumnOverlapThreshold = DefaultOptions.ColumnOverlapThreshold
+	}
+	return o
+}
+
+// headerRank classifies a block's place in the title/header nesting.
+// notHeader blocks are body content and never open a Section of their own.
+type headerRank int
+
+const (
+	notHeader headerRank = iota
+	subheader
+	header
+	title
+)
+
+// classify infers a block's header rank from its BlockType (reliable, if
+// the backend bothered to set it) or its raw layout label (Surya-style
+// strings such as "Title"/"SectionHeader").
+func classify(b models.BlockInfo) headerRank {
+	label := strings.ToLower(b.LayoutLabel)
+	switch {
+	case b.BlockType == models.BlockTypeTitle || label == "title":
+		return title
+	case b.BlockType == models.BlockTypeHeader || label == "header" || label == "sectionheader" || label == "section-header":
+		return header
+	case label == "subheader" || label == "subsectionheader" || label == "subsection-header":
+		return subheader
+	default:
+		return notHeader
+	}
+}
+
+// closesOver reports whether a header of (newRank, newHeight) is
+// equal-or-higher rank than an open section headed by (openRank,
+// openHeight) and should therefore close it rather than nest beneath it.
+// Headers sharing a rank break the tie on BBox.Height, a font-size proxy
+// the layout label alone doesn't carry.
+func closesOver(newRank headerRank, newHeight int, openRank headerRank, openHeight int) bool {
+	if newRank != openRank {
+		return newRank > openRank
+	}
+	return newHeight >= openHeight
+}
+
+// column is a cluster of blocks sharing an X-range, used only to order
+// blocks into reading order before the hierarchy walk.
+type column struct {
+	xMin, xMax int
+	blocks     []models.BlockInfo
+}
+
+// overlapFraction is the overlap between bbox's X-range and c's current
+// X-range, as a fraction of the narrower of the two widths.
+func (c *column) overlapFraction(bbox models.BBox) float64 {
+	lo, hi := max(c.xMin, bbox.X), min(c.xMax, bbox.X+bbox.Width)
+	if hi <= lo {
+		return 0
+	}
+	width := bbox.Width
+	if colWidth := c.xMax - c.xMin; colWidth < width {
+		width = colWidth
+	}
+	if width <= 0 {
+		return 0
+	}
+	return float64(hi-lo) / float64(width)
+}
+
+// orderBlocks reconstructs reading order: blocks are greedily clustered
+// into columns by X-range overlap, columns are ordered left to right, and
+// each column's blocks are sorted top to bottom (then left to right on
+// ties) within it.
+func orderBlocks(blocks []models.BlockInfo, overlapThreshold float64) []models.BlockInfo {
+	var columns []*column
+	for _, b := range blocks {
+		var best *column
+		var bestOverlap float64
+		for _, c := range columns {
+			if o := c.overlapFraction(b.BBox); o > overlapThreshold && o > bestOverlap {
+				best, bestOverlap = c, o
+			}
+		}
+		if best == nil {
+			columns = append(columns, &column{
+				xMin:   b.BBox.X,
+				xMax:   b.BBox.X + b.BBox.Width,
+				blocks: []models.BlockInfo{b},
+			})
+			continue
+		}
+		best.blocks = append(best.blocks, b)
+		if b.BBox.X < best.xMin {
+			best.xMin = b.BBox.X
+		}
+		if right := b.BBox.X + b.BBox.Width; right > best.xMax {
+			best.xMax = right
+		}
+	}
+
+	sort.SliceStable(columns, func(i, j int) bool {
+		return columns[i].xMin+columns[i].xMax < columns[j].xMin+columns[j].xMax
+	})
+
+	ordered := make([]models.BlockInfo, 0, len(blocks))
+	for _, c := range columns {
+		sort.SliceStable(c.blocks, func(i, j int) bool {
+			if c.blocks[i].BBox.Y != c.blocks[j].BBox.Y {
+				return c.blocks[i].BBox.Y < c.blocks[j].BBox.Y
+			}
+			return c.blocks[i].BBox.X < c.blocks[j].BBox.X
+		})
+		ordered = append(ordered, c.blocks...)
+	}
+	return ordered
+}
+
+// node is the internal tree representation HierarchyTree gets flattened
+// from - models.Section has no Children field (Sections is a flat list
+// the way BlockResult already declares it), so nesting only exists here.
+type node struct {
+	id       string
+	title    string
+	children []*node
+}
+
+func (n *node) toMap() map[string]interface{} {
+	children := make([]interface{}, 0, len(n.children))
+	for _, c := range n.children {
+		children = append(children, c.toMap())
+	}
+	return map[string]interface{}{
+		"id":       n.id,
+		"title":    n.title,
+		"children": children,
+	}
+}
+
+// frame is one open Section on BuildHierarchy's stack.
+type frame struct {
+	rank       headerRank
+	height     int
+	sectionIdx int
+	node       *node
+}
+
+// BuildHierarchy groups a flat, backend-ordered block list into Sections
+// and a JSON-renderable HierarchyTree, for the OCR pipeline to attach to
+// its own result (OCRResult/PageResult/BlockResult all share this shape)
+// before persisting it. Blocks is returned unchanged and in its original
+// order - only section grouping uses the column-aware reading order.
+func BuildHierarchy(blocks []models.BlockInfo, opts Options) models.BlockResult {
+	opts = opts.withDefaults()
+	ordered := orderBlocks(blocks, opts.ColumnOverlapThreshold)
+
+	var (
+		sections []models.Section
+		roots    []*node
+		stack    []frame
+		seq      int
+	)
+	nextID := func(prefix string) string {
+		seq++
+		return fmt.Sprintf("%s-%d", prefix, seq)
+	}
+
+	for _, b := range ordered {
+		rank := classify(b)
+
+		if rank == notHeader {
+			if len(stack) == 0 {
+				// Body content before any Title/Header: keep it rather
+				// than dropping it, under a synthetic leading section.
+				id := nextID("body")
+				sections = append(sections, models.Section{ID: id, Type: "body"})
+				n := &node{id: id, title: ""}
+				roots = append(roots, n)
+				stack = append(stack, frame{rank: notHeader, height: 0, sectionIdx: len(sections) - 1, node: n})
+			}
+			top := stack[len(stack)-1]
+			sections[top.sectionIdx].Blocks = append(sections[top.sectionIdx].Blocks, b)
+			continue
+		}
+
+		for len(stack) > 0 && closesOver(rank, b.BBox.Height, stack[len(stack)-1].rank, stack[len(stack)-1].height) {
+			stack = stack[:len(stack)-1]
+		}
+
+		sectionType := "header"
+		switch rank {
+		case title:
+			sectionType = "title"
+		case subheader:
+			sectionType = "subheader"
+		}
+
+		id := nextID(sectionType)
+		sections = append(sections, models.Section{ID: id, Type: sectionType, Blocks: []models.BlockInfo{b}})
+		n := &node{id: id, title: b.Text}
+
+		if len(stack) == 0 {
+			roots = append(roots, n)
+		} else {
+			parent := stack[len(stack)-1].node
+			parent.children = append(parent.children, n)
+		}
+		stack = append(stack, frame{rank: rank, height: b.BBox.Height, sectionIdx: len(sections) - 1, node: n})
+	}
+
+	root := &node{id: "root", children: roots}
+
+	totalConfidence := 0.0
+	for _, b := range blocks {
+		totalConfidence += b.Confidence
+	}
+	avgConfidence := 0.0
+	if len(blocks) > 0 {
+		avgConfidence = totalConfidence / float64(len(blocks))
+	}
+
+	return models.BlockResult{
+		Blocks:        blocks,
+		TotalBlocks:   len(blocks),
+		AverageConf:   avgConfidence,
+		Sections:      sections,
+		HierarchyTree: root.toMap(),
+	}
+}
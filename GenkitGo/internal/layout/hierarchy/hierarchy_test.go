@@ -0,0 +1,116 @@
+package hierarchy
+
+import (
+	"testing"
+
+	"github.com/plobin/genkitgo/internal/models"
+)
+
+func TestBuildHierarchyGroupsBlocksUnderHeaders(t *testing.T) {
+	blocks := []models.BlockInfo{
+		{ID: 1, Text: "Document Title", BlockType: models.BlockTypeTitle, BBox: models.BBox{X: 0, Y: 0, Width: 100, Height: 20}},
+		{ID: 2, Text: "Intro paragraph", BBox: models.BBox{X: 0, Y: 20, Width: 100, Height: 20}},
+		{ID: 3, Text: "Section One", BlockType: models.BlockTypeHeader, BBox: models.BBox{X: 0, Y: 40, Width: 100, Height: 15}},
+		{ID: 4, Text: "Body under section one", BBox: models.BBox{X: 0, Y: 55, Width: 100, Height: 20}},
+	}
+
+	result := BuildHierarchy(blocks, Options{})
+
+	if result.TotalBlocks != 4 {
+		t.Fatalf("expected TotalBlocks 4, got %d", result.TotalBlocks)
+	}
+	if len(result.Sections) != 2 {
+		t.Fatalf("expected 2 sections (title and header, each with its following body folded in), got %d: %+v", len(result.Sections), result.Sections)
+	}
+
+	titleSection := result.Sections[0]
+	if titleSection.Type != "title" || len(titleSection.Blocks) != 2 || titleSection.Blocks[0].ID != 1 || titleSection.Blocks[1].ID != 2 {
+		t.Fatalf("expected first section to be the title holding blocks 1 and 2, got %+v", titleSection)
+	}
+
+	headerSection := result.Sections[1]
+	if headerSection.Type != "header" || len(headerSection.Blocks) != 2 || headerSection.Blocks[0].ID != 3 || headerSection.Blocks[1].ID != 4 {
+		t.Fatalf("expected second section to be the header holding blocks 3 and 4, got %+v", headerSection)
+	}
+}
+
+func TestBuildHierarchyLeadingBodyGetsSyntheticSection(t *testing.T) {
+	blocks := []models.BlockInfo{
+		{ID: 1, Text: "no header before me", BBox: models.BBox{X: 0, Y: 0, Width: 100, Height: 20}},
+	}
+
+	result := BuildHierarchy(blocks, Options{})
+
+	if len(result.Sections) != 1 || result.Sections[0].Type != "body" {
+		t.Fatalf("expected a single synthetic body section, got %+v", result.Sections)
+	}
+	if len(result.Sections[0].Blocks) != 1 || result.Sections[0].Blocks[0].ID != 1 {
+		t.Fatalf("expected the leading block to land in the synthetic section, got %+v", result.Sections[0])
+	}
+}
+
+func TestBuildHierarchySameRankHeaderClosesOverPreviousOnHeight(t *testing.T) {
+	blocks := []models.BlockInfo{
+		{ID: 1, Text: "Section A", BlockType: models.BlockTypeHeader, BBox: models.BBox{X: 0, Y: 0, Width: 100, Height: 20}},
+		{ID: 2, Text: "Section B", BlockType: models.BlockTypeHeader, BBox: models.BBox{X: 0, Y: 20, Width: 100, Height: 20}},
+	}
+
+	result := BuildHierarchy(blocks, Options{})
+
+	if len(result.Sections) != 2 {
+		t.Fatalf("expected two sibling header sections, got %d: %+v", len(result.Sections), result.Sections)
+	}
+	if len(result.HierarchyTree["children"].([]interface{})) != 2 {
+		t.Fatalf("expected two root-level tree nodes (B closes over A rather than nesting), got %+v", result.HierarchyTree)
+	}
+}
+
+func TestBuildHierarchyOrdersTwoColumnsLeftToRight(t *testing.T) {
+	blocks := []models.BlockInfo{
+		{ID: 1, Text: "right column top", BBox: models.BBox{X: 200, Y: 0, Width: 100, Height: 20}},
+		{ID: 2, Text: "left column top", BBox: models.BBox{X: 0, Y: 0, Width: 100, Height: 20}},
+		{ID: 3, Text: "left column bottom", BBox: models.BBox{X: 0, Y: 20, Width: 100, Height: 20}},
+		{ID: 4, Text: "right column bottom", BBox: models.BBox{X: 200, Y: 20, Width: 100, Height: 20}},
+	}
+
+	result := BuildHierarchy(blocks, Options{})
+
+	if result.TotalBlocks != 4 {
+		t.Fatalf("expected TotalBlocks 4, got %d", result.TotalBlocks)
+	}
+	if len(result.Sections) != 1 {
+		t.Fatalf("expected a single synthetic body section, got %+v", result.Sections)
+	}
+
+	got := make([]int, len(result.Sections[0].Blocks))
+	for i, b := range result.Sections[0].Blocks {
+		got[i] = b.ID
+	}
+	want := []int{2, 3, 1, 4}
+	for i := range want {
+		if i >= len(got) || got[i] != want[i] {
+			t.Fatalf("expected reading order %v (left column then right column), got %v", want, got)
+		}
+	}
+}
+
+func TestBuildHierarchyAverageConfidence(t *testing.T) {
+	blocks := []models.BlockInfo{
+		{ID: 1, Confidence: 1.0},
+		{ID: 2, Confidence: 0.5},
+	}
+
+	result := BuildHierarchy(blocks, Options{})
+
+	if result.AverageConf != 0.75 {
+		t.Fatalf("expected average confidence 0.75, got %f", result.AverageConf)
+	}
+}
+
+func TestBuildHierarchyEmptyInput(t *testing.T) {
+	result := BuildHierarchy(nil, Options{})
+
+	if result.TotalBlocks != 0 || len(result.Sections) != 0 || result.AverageConf != 0 {
+		t.Fatalf("expected an empty result for no blocks, got %+v", result)
+	}
+}
@@ -0,0 +1,36 @@
+package logger
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/google/uuid"
+)
+
+// Middleware assigns each request a UUID request ID (stored in context via
+// WithRequestID, independent of chi's own middleware.RequestID), and logs
+// its method, path, status, and duration once it completes. It replaces
+// chi's middleware.Logger so request logs carry a correlation ID that
+// services can also log against via slog.InfoContext.
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID := uuid.New().String()
+		ctx := WithRequestID(r.Context(), requestID)
+		r = r.WithContext(ctx)
+
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+		start := time.Now()
+
+		next.ServeHTTP(ww, r)
+
+		slog.InfoContext(ctx, "request",
+			"request_id", requestID,
+			"method", r.Method,
+			"path", r.URL.Path,
+			"status", ww.Status(),
+			"duration_ms", time.Since(start).Milliseconds(),
+		)
+	})
+}
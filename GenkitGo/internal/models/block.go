@@ -4,16 +4,16 @@ package models
 type BlockType string
 
 const (
-	BlockTypeText      BlockType = "text"
-	BlockTypeTitle     BlockType = "title"
-	BlockTypeTable     BlockType = "table"
-	BlockTypeImage     BlockType = "image"
-	BlockTypeEquation  BlockType = "equation"
-	BlockTypeFootnote  BlockType = "footnote"
-	BlockTypeHeader    BlockType = "header"
-	BlockTypeFooter    BlockType = "footer"
-	BlockTypeListItem  BlockType = "list_item"
-	BlockTypeUnknown   BlockType = "unknown"
+	BlockTypeText     BlockType = "text"
+	BlockTypeTitle    BlockType = "title"
+	BlockTypeTable    BlockType = "table"
+	BlockTypeImage    BlockType = "image"
+	BlockTypeEquation BlockType = "equation"
+	BlockTypeFootnote BlockType = "footnote"
+	BlockTypeHeader   BlockType = "header"
+	BlockTypeFooter   BlockType = "footer"
+	BlockTypeListItem BlockType = "list_item"
+	BlockTypeUnknown  BlockType = "unknown"
 )
 
 // BBox represents a bounding box
@@ -40,6 +40,7 @@ type BlockInfo struct {
 	BlockType   BlockType `json:"block_type"`
 	Language    string    `json:"language,omitempty"`
 	LayoutLabel string    `json:"layout_label,omitempty"` // Surya layout label (Title, Text, Table, etc.)
+	Backend     string    `json:"backend,omitempty"`      // name of the OCR backend that produced this block
 }
 
 // BlockResult represents blocks with metadata
@@ -0,0 +1,26 @@
+package models
+
+// BlockCorrection captures one block's OCR post-correction diff.
+type BlockCorrection struct {
+	BlockID    int    `json:"block_id"`
+	PageNumber int    `json:"page_number"`
+	Original   string `json:"original"`
+	Corrected  string `json:"corrected"`
+	Changed    bool   `json:"changed"`
+}
+
+// CorrectBlocksResult is the response body for POST /api/requests/{id}/correct.
+type CorrectBlocksResult struct {
+	RequestID   string            `json:"request_id"`
+	DryRun      bool              `json:"dry_run"`
+	Corrections []BlockCorrection `json:"corrections"`
+	BlocksFixed int               `json:"blocks_fixed"`
+}
+
+// ExtractResult is the response body for POST /api/requests/{id}/extract.
+type ExtractResult struct {
+	RequestID          string                 `json:"request_id"`
+	Data               map[string]interface{} `json:"data"`
+	PerFieldConfidence map[string]float64     `json:"per_field_confidence"`
+	SourceBlockIDs     map[string][]int       `json:"source_block_ids"`
+}
@@ -2,12 +2,19 @@ package models
 
 // OCROptions represents OCR processing options
 type OCROptions struct {
-	MergeBlocks        bool    `json:"merge_blocks"`
-	MergeThreshold     int     `json:"merge_threshold"`
+	MergeBlocks         bool    `json:"merge_blocks"`
+	MergeThreshold      int     `json:"merge_threshold"`
 	ConfidenceThreshold float64 `json:"confidence_threshold"`
-	CreateSections     bool    `json:"create_sections"`
-	BuildHierarchyTree bool    `json:"build_hierarchy_tree"`
-	Language           string  `json:"language"`
+	CreateSections      bool    `json:"create_sections"`
+	BuildHierarchyTree  bool    `json:"build_hierarchy_tree"`
+	Language            string  `json:"language"`
+
+	// Preprocessing applied to the image before OCR - see
+	// internal/services/Image/Preprocess.
+	Deskew   bool    `json:"deskew"`
+	Binarize string  `json:"binarize,omitempty"` // "otsu", "sauvola", or "" to skip
+	Denoise  bool    `json:"denoise"`
+	Upscale  float64 `json:"upscale,omitempty"` // scale factor, e.g. 2; <=1 skips it
 }
 
 // OCRResult represents the result of OCR processing
@@ -23,12 +30,12 @@ type OCRResult struct {
 
 // PageResult represents OCR result for a single page
 type PageResult struct {
-	PageNumber      int                    `json:"page_number"`
-	Blocks          []BlockInfo            `json:"blocks"`
-	TotalBlocks     int                    `json:"total_blocks"`
-	AverageConf     float64                `json:"average_confidence"`
-	OriginalImage   string                 `json:"original_image"`
-	Visualization   string                 `json:"visualization,omitempty"`
-	Sections        []Section              `json:"sections,omitempty"`
-	HierarchyTree   map[string]interface{} `json:"hierarchy_tree,omitempty"`
+	PageNumber    int                    `json:"page_number"`
+	Blocks        []BlockInfo            `json:"blocks"`
+	TotalBlocks   int                    `json:"total_blocks"`
+	AverageConf   float64                `json:"average_confidence"`
+	OriginalImage string                 `json:"original_image"`
+	Visualization string                 `json:"visualization,omitempty"`
+	Sections      []Section              `json:"sections,omitempty"`
+	HierarchyTree map[string]interface{} `json:"hierarchy_tree,omitempty"`
 }
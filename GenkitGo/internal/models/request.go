@@ -28,6 +28,7 @@ type RequestMetadata struct {
 	FileSize     int64         `json:"file_size"`
 	TotalPages   int           `json:"total_pages"`
 	Status       RequestStatus `json:"status"`
+	Progress     int           `json:"progress"` // 0-100, updated as pages finish OCR while Status is "processing"
 	CreatedAt    time.Time     `json:"created_at"`
 	UpdatedAt    time.Time     `json:"updated_at"`
 	ErrorMessage string        `json:"error_message,omitempty"`
@@ -35,13 +36,13 @@ type RequestMetadata struct {
 
 // RequestSummary represents a summary of processing results
 type RequestSummary struct {
-	RequestID       string              `json:"request_id"`
-	TotalPages      int                 `json:"total_pages"`
-	TotalBlocks     int                 `json:"total_blocks"`
-	AverageConf     float64             `json:"average_confidence"`
-	ProcessingTime  float64             `json:"processing_time_seconds"`
-	Pages           []PageSummary       `json:"pages"`
-	OCRMetadata     map[string]interface{} `json:"ocr_metadata,omitempty"`
+	RequestID      string                 `json:"request_id"`
+	TotalPages     int                    `json:"total_pages"`
+	TotalBlocks    int                    `json:"total_blocks"`
+	AverageConf    float64                `json:"average_confidence"`
+	ProcessingTime float64                `json:"processing_time_seconds"`
+	Pages          []PageSummary          `json:"pages"`
+	OCRMetadata    map[string]interface{} `json:"ocr_metadata,omitempty"`
 }
 
 // PageSummary represents a summary of a single page
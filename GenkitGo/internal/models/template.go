@@ -2,13 +2,41 @@ package models
 
 import "time"
 
+// NormalizedBBox is a bounding box expressed as fractions (0..1) of a
+// page's pixel dimensions, so a field region stays valid across scans of
+// the same document taken at a different DPI or resolution.
+type NormalizedBBox struct {
+	X      float64 `json:"x"`
+	Y      float64 `json:"y"`
+	Width  float64 `json:"width"`
+	Height float64 `json:"height"`
+}
+
 type TemplateField struct {
-	Name        string   `json:"name"`
-	Type        string   `json:"type"` // text, number, date, etc.
-	Required    bool     `json:"required"`
-	Description string   `json:"description,omitempty"`
-	BlockIDs    []int    `json:"block_ids,omitempty"` // Associated block IDs
-	Validation  string   `json:"validation,omitempty"` // Validation rules
+	Name        string `json:"name"`
+	Type        string `json:"type"` // text, number, date, money, int, enum
+	Required    bool   `json:"required"`
+	Description string `json:"description,omitempty"`
+	BlockIDs    []int  `json:"block_ids,omitempty"`  // Associated block IDs
+	Validation  string `json:"validation,omitempty"` // Validation rules
+
+	// Region selects OCR blocks whose bbox intersects it. Mutually
+	// exclusive with AnchorText - set one or the other.
+	Region *NormalizedBBox `json:"region,omitempty"`
+
+	// AnchorText locates the field by fuzzy-matching this text against the
+	// page's blocks, then applies Offset (relative to the matched block's
+	// position) to find the field's own blocks.
+	AnchorText string          `json:"anchor_text,omitempty"`
+	Offset     *NormalizedBBox `json:"offset,omitempty"`
+
+	// Regex, when set, is applied to the concatenated block text before
+	// type coercion; its first capture group is used, or the whole match
+	// if the pattern has none.
+	Regex string `json:"regex,omitempty"`
+
+	// EnumValues restricts a "enum"-typed field to a fixed set of values.
+	EnumValues []string `json:"enum_values,omitempty"`
 }
 
 type Template struct {
@@ -25,3 +53,39 @@ type TemplateCreateRequest struct {
 	Description string          `json:"description,omitempty"`
 	Fields      []TemplateField `json:"fields"`
 }
+
+// TemplateApplyRequest asks that a template's fields be resolved against
+// one indexed OCR request. PageNumber is optional; when nil, every page
+// indexed for RequestID is searched field-by-field.
+type TemplateApplyRequest struct {
+	RequestID  string `json:"request_id"`
+	PageNumber *int   `json:"page_number,omitempty"`
+}
+
+// TemplateFieldResult is the resolved value for one TemplateField.
+type TemplateFieldResult struct {
+	Value            string   `json:"value"`
+	Raw              string   `json:"raw"`
+	Confidence       float64  `json:"confidence"`
+	SourceBlockIDs   []int    `json:"source_block_ids"`
+	ValidationErrors []string `json:"validation_errors,omitempty"`
+}
+
+// TemplateApplyResult is the outcome of applying a template to an OCR
+// request: a resolved value per matched field, plus the names of fields
+// that couldn't be resolved to any block.
+type TemplateApplyResult struct {
+	Fields    map[string]TemplateFieldResult `json:"fields"`
+	Unmatched []string                       `json:"unmatched"`
+}
+
+// TemplateLearnRequest authors a template by example: given a page already
+// OCR'd and a user-supplied value per field, the field's region is
+// back-solved from whichever blocks best match that value.
+type TemplateLearnRequest struct {
+	Name        string            `json:"name"`
+	Description string            `json:"description,omitempty"`
+	RequestID   string            `json:"request_id"`
+	PageNumber  int               `json:"page_number"`
+	FieldValues map[string]string `json:"field_values"`
+}
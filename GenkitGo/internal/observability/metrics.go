@@ -0,0 +1,52 @@
+package observability
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// httpRequestsTotal and httpRequestDuration are recorded by Middleware for
+// every request; llmRequestDuration is recorded directly by LLMClient
+// around each completion. All three register against the default registry
+// the way prometheus's own promauto helpers expect, so /metrics (wired via
+// Handler) reports them alongside Go's standard process/runtime metrics.
+var (
+	httpRequestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_requests_total",
+		Help: "Total HTTP requests handled, by route and status code.",
+	}, []string{"route", "status"})
+
+	httpRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "HTTP handler latency in seconds, by route.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"route"})
+
+	llmRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "llm_request_duration_seconds",
+		Help:    "LLM chat completion latency in seconds, by model.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"model"})
+)
+
+// RecordHTTPRequest records one completed HTTP request for http_requests_total
+// and http_request_duration_seconds.
+func RecordHTTPRequest(route, status string, duration time.Duration) {
+	httpRequestsTotal.WithLabelValues(route, status).Inc()
+	httpRequestDuration.WithLabelValues(route).Observe(duration.Seconds())
+}
+
+// RecordLLMRequest records one completed LLM chat completion for
+// llm_request_duration_seconds.
+func RecordLLMRequest(model string, duration time.Duration) {
+	llmRequestDuration.WithLabelValues(model).Observe(duration.Seconds())
+}
+
+// Handler serves the Prometheus text exposition format for /metrics.
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
@@ -0,0 +1,44 @@
+package observability
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// Middleware starts a server span and records http_requests_total /
+// http_request_duration_seconds for every request, alongside
+// logger.Middleware. The route label is chi's matched route pattern (e.g.
+// "/api/requests/{id}/status") rather than the raw path, so metrics don't
+// fan out one series per request ID; it falls back to the raw path if chi
+// hasn't set one (e.g. a 404 that matched no route).
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+
+		ctx, span := Tracer("http").Start(r.Context(), r.Method+" "+r.URL.Path)
+		defer span.End()
+		r = r.WithContext(ctx)
+
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+		next.ServeHTTP(ww, r)
+
+		route := r.URL.Path
+		if pattern := chi.RouteContext(r.Context()).RoutePattern(); pattern != "" {
+			route = pattern
+		}
+
+		status := ww.Status()
+		span.SetAttributes(
+			attribute.String("http.method", r.Method),
+			attribute.String("http.route", route),
+			attribute.Int("http.status_code", status),
+		)
+
+		RecordHTTPRequest(route, strconv.Itoa(status), time.Since(start))
+	})
+}
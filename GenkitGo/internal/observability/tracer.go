@@ -0,0 +1,61 @@
+// Package observability wires OpenTelemetry tracing and Prometheus metrics
+// into the server/worker's hot paths: object storage, the LLM client, and
+// HTTP handlers. With no OTLP endpoint configured, Init is a no-op - otel's
+// global tracer provider is already a no-op implementation until Init
+// replaces it, so every Tracer(...).Start call degrades to a cheap no-op
+// span rather than an error, and tests/stub handlers keep passing
+// unmodified.
+package observability
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// serviceName tags every span this process emits, so cmd/server and
+// cmd/worker are distinguishable in the configured OTLP backend.
+const serviceName = "genkitgo"
+
+// Init configures the global TracerProvider from OTEL_EXPORTER_OTLP_ENDPOINT
+// (an OTLP/HTTP collector address, e.g. "localhost:4318"). With that env
+// var unset, it leaves the default no-op provider in place and returns a
+// no-op shutdown func. Call the returned shutdown during graceful
+// shutdown to flush any buffered spans.
+func Init(ctx context.Context) (shutdown func(context.Context) error, err error) {
+	endpoint := os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT")
+	if endpoint == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporter, err := otlptracehttp.New(ctx, otlptracehttp.WithEndpoint(endpoint), otlptracehttp.WithInsecure())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP trace exporter: %w", err)
+	}
+
+	res, err := resource.New(ctx, resource.WithAttributes(semconv.ServiceName(serviceName)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build OTel resource: %w", err)
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(tp)
+
+	return tp.Shutdown, nil
+}
+
+// Tracer returns a named tracer off the global provider - real if Init
+// configured an exporter, a no-op otherwise.
+func Tracer(name string) trace.Tracer {
+	return otel.Tracer(name)
+}
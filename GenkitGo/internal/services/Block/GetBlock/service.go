@@ -3,28 +3,25 @@ package GetBlock
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"os"
-	"path/filepath"
 
 	"github.com/plobin/genkitgo/internal/models"
+	"github.com/plobin/genkitgo/internal/storage"
 )
 
 type Service struct {
-	baseDir string
+	store storage.Storage
 }
 
-func NewService(baseDir string) *Service {
-	return &Service{
-		baseDir: baseDir,
-	}
+func NewService(store storage.Storage) *Service {
+	return &Service{store: store}
 }
 
 // Execute retrieves a specific block by ID from request storage
 func (s *Service) Execute(ctx context.Context, requestID string, blockID int) (*models.BlockInfo, error) {
 	// Load request metadata to get page information
-	metadataPath := filepath.Join(s.baseDir, requestID, "metadata.json")
-	data, err := os.ReadFile(metadataPath)
+	data, err := s.store.Get(ctx, requestID+"/metadata.json")
 	if err != nil {
 		return nil, fmt.Errorf("request not found: %w", err)
 	}
@@ -36,10 +33,12 @@ func (s *Service) Execute(ctx context.Context, requestID string, blockID int) (*
 
 	// Search through all pages for the block
 	for i := 1; i <= metadata.TotalPages; i++ {
-		pagePath := filepath.Join(s.baseDir, requestID, fmt.Sprintf("page_%d.json", i))
-		pageData, err := os.ReadFile(pagePath)
+		pageData, err := s.store.Get(ctx, fmt.Sprintf("%s/page_%d.json", requestID, i))
 		if err != nil {
-			continue // Skip missing pages
+			if errors.Is(err, storage.ErrNotExist) {
+				continue // Skip missing pages
+			}
+			return nil, fmt.Errorf("failed to read page %d: %w", i, err)
 		}
 
 		var pageResult models.OCRResult
@@ -3,28 +3,25 @@ package UpdateBlock
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"os"
-	"path/filepath"
 
 	"github.com/plobin/genkitgo/internal/models"
+	"github.com/plobin/genkitgo/internal/storage"
 )
 
 type Service struct {
-	baseDir string
+	store storage.Storage
 }
 
-func NewService(baseDir string) *Service {
-	return &Service{
-		baseDir: baseDir,
-	}
+func NewService(store storage.Storage) *Service {
+	return &Service{store: store}
 }
 
 // Execute updates a specific block's text
 func (s *Service) Execute(ctx context.Context, requestID string, blockID int, newText string) (*models.BlockInfo, error) {
 	// Load request metadata
-	metadataPath := filepath.Join(s.baseDir, requestID, "metadata.json")
-	data, err := os.ReadFile(metadataPath)
+	data, err := s.store.Get(ctx, requestID+"/metadata.json")
 	if err != nil {
 		return nil, fmt.Errorf("request not found: %w", err)
 	}
@@ -36,10 +33,13 @@ func (s *Service) Execute(ctx context.Context, requestID string, blockID int, ne
 
 	// Search through all pages for the block
 	for i := 1; i <= metadata.TotalPages; i++ {
-		pagePath := filepath.Join(s.baseDir, requestID, fmt.Sprintf("page_%d.json", i))
-		pageData, err := os.ReadFile(pagePath)
+		pageKey := fmt.Sprintf("%s/page_%d.json", requestID, i)
+		pageData, err := s.store.Get(ctx, pageKey)
 		if err != nil {
-			continue
+			if errors.Is(err, storage.ErrNotExist) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to read page %d: %w", i, err)
 		}
 
 		var pageResult models.OCRResult
@@ -66,7 +66,7 @@ func (s *Service) Execute(ctx context.Context, requestID string, blockID int, ne
 				return nil, fmt.Errorf("failed to marshal updated page: %w", err)
 			}
 
-			if err := os.WriteFile(pagePath, updatedData, 0644); err != nil {
+			if err := s.store.Put(ctx, pageKey, updatedData); err != nil {
 				return nil, fmt.Errorf("failed to save updated page: %w", err)
 			}
 
@@ -1,53 +1,68 @@
 package Storage
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"os"
-	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/plobin/genkitgo/internal/models"
+	"github.com/plobin/genkitgo/internal/observability"
+	"github.com/plobin/genkitgo/internal/storage"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
-// Service handles file storage and retrieval
+// metadataKey is the well-known suffix every request writes its metadata
+// under, used by ListRequests to enumerate request IDs from object keys.
+const metadataKey = "metadata.json"
+
+// Service handles request metadata/summary persistence against a Storage
+// driver (see internal/storage) - local disk or an S3-compatible bucket.
 type Service struct {
-	baseDir string
+	store storage.Storage
 }
 
-// NewService creates a new storage service
-func NewService(baseDir string) *Service {
-	if baseDir == "" {
-		baseDir = "output"
-	}
+// NewService creates a new storage service backed by store.
+func NewService(store storage.Storage) *Service {
+	return &Service{store: store}
+}
 
-	// Create base directory if it doesn't exist
-	os.MkdirAll(baseDir, 0755)
+// startSpan opens a span for one Service operation, tagged with the
+// request_id and op attributes every method below reports, so a trace
+// backend can group storage latency by request regardless of which
+// method (or underlying driver) served it. bytes is set separately once
+// the method knows how large the payload was.
+func startSpan(ctx context.Context, op, requestID string) (context.Context, trace.Span) {
+	ctx, span := observability.Tracer("storage").Start(ctx, "Storage."+op)
+	span.SetAttributes(
+		attribute.String("op", op),
+		attribute.String("request_id", requestID),
+	)
+	return ctx, span
+}
 
-	return &Service{
-		baseDir: baseDir,
+// endSpan records bytes transferred and the call's outcome, then closes
+// the span. Call via defer right after startSpan.
+func endSpan(span trace.Span, bytes int, err error) {
+	span.SetAttributes(attribute.Int("bytes", bytes))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
 	}
+	span.End()
 }
 
 // CreateRequest creates a new request structure
-func (s *Service) CreateRequest(filename string, fileType models.RequestType, fileSize int64, totalPages int) (string, error) {
+func (s *Service) CreateRequest(ctx context.Context, filename string, fileType models.RequestType, fileSize int64, totalPages int) (_ string, err error) {
 	requestID := uuid.New().String()
-	requestDir := filepath.Join(s.baseDir, requestID)
 
-	// Create directories
-	dirs := []string{
-		requestDir,
-		filepath.Join(requestDir, "pages"),
-	}
+	ctx, span := startSpan(ctx, "CreateRequest", requestID)
+	defer func() { endSpan(span, 0, err) }()
 
-	for _, dir := range dirs {
-		if err := os.MkdirAll(dir, 0755); err != nil {
-			return "", fmt.Errorf("failed to create directory %s: %w", dir, err)
-		}
-	}
-
-	// Create metadata
 	metadata := models.RequestMetadata{
 		RequestID:    requestID,
 		OriginalFile: filename,
@@ -59,8 +74,7 @@ func (s *Service) CreateRequest(filename string, fileType models.RequestType, fi
 		UpdatedAt:    time.Now(),
 	}
 
-	// Save metadata
-	if err := s.SaveMetadata(requestID, &metadata); err != nil {
+	if err := s.SaveMetadata(ctx, requestID, &metadata); err != nil {
 		return "", fmt.Errorf("failed to save metadata: %w", err)
 	}
 
@@ -68,25 +82,30 @@ func (s *Service) CreateRequest(filename string, fileType models.RequestType, fi
 }
 
 // SaveMetadata saves request metadata
-func (s *Service) SaveMetadata(requestID string, metadata *models.RequestMetadata) error {
-	metadataPath := filepath.Join(s.baseDir, requestID, "metadata.json")
-	
+func (s *Service) SaveMetadata(ctx context.Context, requestID string, metadata *models.RequestMetadata) (err error) {
+	ctx, span := startSpan(ctx, "SaveMetadata", requestID)
+	var n int
+	defer func() { endSpan(span, n, err) }()
+
 	data, err := json.MarshalIndent(metadata, "", "  ")
 	if err != nil {
 		return fmt.Errorf("failed to marshal metadata: %w", err)
 	}
-
-	return os.WriteFile(metadataPath, data, 0644)
+	n = len(data)
+	return s.store.Put(ctx, requestID+"/"+metadataKey, data)
 }
 
 // GetMetadata retrieves request metadata
-func (s *Service) GetMetadata(requestID string) (*models.RequestMetadata, error) {
-	metadataPath := filepath.Join(s.baseDir, requestID, "metadata.json")
+func (s *Service) GetMetadata(ctx context.Context, requestID string) (_ *models.RequestMetadata, err error) {
+	ctx, span := startSpan(ctx, "GetMetadata", requestID)
+	var n int
+	defer func() { endSpan(span, n, err) }()
 
-	data, err := os.ReadFile(metadataPath)
+	data, err := s.store.Get(ctx, requestID+"/"+metadataKey)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read metadata: %w", err)
 	}
+	n = len(data)
 
 	var metadata models.RequestMetadata
 	if err := json.Unmarshal(data, &metadata); err != nil {
@@ -97,25 +116,30 @@ func (s *Service) GetMetadata(requestID string) (*models.RequestMetadata, error)
 }
 
 // SaveSummary saves request summary
-func (s *Service) SaveSummary(requestID string, summary *models.RequestSummary) error {
-	summaryPath := filepath.Join(s.baseDir, requestID, "summary.json")
+func (s *Service) SaveSummary(ctx context.Context, requestID string, summary *models.RequestSummary) (err error) {
+	ctx, span := startSpan(ctx, "SaveSummary", requestID)
+	var n int
+	defer func() { endSpan(span, n, err) }()
 
 	data, err := json.MarshalIndent(summary, "", "  ")
 	if err != nil {
 		return fmt.Errorf("failed to marshal summary: %w", err)
 	}
-
-	return os.WriteFile(summaryPath, data, 0644)
+	n = len(data)
+	return s.store.Put(ctx, requestID+"/summary.json", data)
 }
 
 // GetSummary retrieves request summary
-func (s *Service) GetSummary(requestID string) (*models.RequestSummary, error) {
-	summaryPath := filepath.Join(s.baseDir, requestID, "summary.json")
+func (s *Service) GetSummary(ctx context.Context, requestID string) (_ *models.RequestSummary, err error) {
+	ctx, span := startSpan(ctx, "GetSummary", requestID)
+	var n int
+	defer func() { endSpan(span, n, err) }()
 
-	data, err := os.ReadFile(summaryPath)
+	data, err := s.store.Get(ctx, requestID+"/summary.json")
 	if err != nil {
 		return nil, fmt.Errorf("failed to read summary: %w", err)
 	}
+	n = len(data)
 
 	var summary models.RequestSummary
 	if err := json.Unmarshal(data, &summary); err != nil {
@@ -126,33 +150,46 @@ func (s *Service) GetSummary(requestID string) (*models.RequestSummary, error) {
 }
 
 // SavePageResult saves page OCR result
-func (s *Service) SavePageResult(requestID string, pageNum int, result *models.PageResult) error {
-	pageDir := filepath.Join(s.baseDir, requestID, "pages", fmt.Sprintf("%03d", pageNum))
-	os.MkdirAll(pageDir, 0755)
-
-	resultPath := filepath.Join(pageDir, "result.json")
+func (s *Service) SavePageResult(ctx context.Context, requestID string, pageNum int, result *models.PageResult) (err error) {
+	ctx, span := startSpan(ctx, "SavePageResult", requestID)
+	var n int
+	defer func() { endSpan(span, n, err) }()
 
 	data, err := json.MarshalIndent(result, "", "  ")
 	if err != nil {
 		return fmt.Errorf("failed to marshal page result: %w", err)
 	}
-
-	return os.WriteFile(resultPath, data, 0644)
+	n = len(data)
+	key := fmt.Sprintf("%s/pages/%03d/result.json", requestID, pageNum)
+	return s.store.Put(ctx, key, data)
 }
 
-// ListRequests lists all request IDs
-func (s *Service) ListRequests() ([]string, error) {
-	entries, err := os.ReadDir(s.baseDir)
+// ListRequests lists all request IDs that have a metadata.json object.
+func (s *Service) ListRequests(ctx context.Context) (_ []string, err error) {
+	ctx, span := startSpan(ctx, "ListRequests", "")
+	defer func() { endSpan(span, 0, err) }()
+
+	infos, err := s.store.List(ctx, "")
 	if err != nil {
-		return nil, fmt.Errorf("failed to read base directory: %w", err)
+		return nil, fmt.Errorf("failed to list requests: %w", err)
 	}
 
 	var requests []string
-	for _, entry := range entries {
-		if entry.IsDir() {
-			requests = append(requests, entry.Name())
+	for _, info := range infos {
+		if dir, name := splitKey(info.Key); name == metadataKey && dir != "" {
+			requests = append(requests, dir)
 		}
 	}
-
 	return requests, nil
 }
+
+// splitKey splits a "<requestID>/.../name" key into its top-level
+// requestID directory and final path segment.
+func splitKey(key string) (dir, name string) {
+	idx := strings.Index(key, "/")
+	if idx < 0 {
+		return "", key
+	}
+	last := strings.LastIndex(key, "/")
+	return key[:idx], key[last+1:]
+}
@@ -2,13 +2,14 @@ package GetImage
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"os"
-	"path/filepath"
+
+	"github.com/plobin/genkitgo/internal/storage"
 )
 
 type Service struct {
-	baseDir string
+	store storage.Storage
 }
 
 type ImageResult struct {
@@ -17,10 +18,8 @@ type ImageResult struct {
 	Data        []byte `json:"-"` // Binary data not included in JSON
 }
 
-func NewService(baseDir string) *Service {
-	return &Service{
-		baseDir: baseDir,
-	}
+func NewService(store storage.Storage) *Service {
+	return &Service{store: store}
 }
 
 // Execute retrieves the original image file for a specific page
@@ -32,26 +31,26 @@ func (s *Service) Execute(ctx context.Context, requestID string, pageNumber int)
 	// Try different image extensions
 	extensions := []string{".png", ".jpg", ".jpeg"}
 	for _, ext := range extensions {
-		imagePath := filepath.Join(s.baseDir, requestID, fmt.Sprintf("page_%d%s", pageNumber, ext))
-
-		if _, err := os.Stat(imagePath); err == nil {
-			// File exists, read it
-			data, err := os.ReadFile(imagePath)
-			if err != nil {
-				return nil, fmt.Errorf("failed to read image: %w", err)
-			}
+		key := fmt.Sprintf("%s/page_%d%s", requestID, pageNumber, ext)
 
-			contentType := "image/png"
-			if ext == ".jpg" || ext == ".jpeg" {
-				contentType = "image/jpeg"
+		data, err := s.store.Get(ctx, key)
+		if err != nil {
+			if errors.Is(err, storage.ErrNotExist) {
+				continue
 			}
+			return nil, fmt.Errorf("failed to read image: %w", err)
+		}
 
-			return &ImageResult{
-				FilePath:    imagePath,
-				ContentType: contentType,
-				Data:        data,
-			}, nil
+		contentType := "image/png"
+		if ext == ".jpg" || ext == ".jpeg" {
+			contentType = "image/jpeg"
 		}
+
+		return &ImageResult{
+			FilePath:    key,
+			ContentType: contentType,
+			Data:        data,
+		}, nil
 	}
 
 	return nil, fmt.Errorf("image for page %d not found in request %s", pageNumber, requestID)
@@ -0,0 +1,164 @@
+package Preprocess
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+)
+
+// Sauvola's local-threshold parameters. k and R are the values from the
+// original paper; window is the odd-sized box (in pixels) its local
+// mean/stddev are computed over.
+const (
+	sauvolaWindow = 15
+	sauvolaK      = 0.5
+	sauvolaR      = 128.0
+)
+
+// binarize reduces gray to pure black (0) / white (255) pixels using the
+// named adaptive-threshold method.
+func binarize(gray *image.Gray, method string) (*image.Gray, error) {
+	switch method {
+	case "otsu":
+		return otsuThreshold(gray), nil
+	case "sauvola":
+		return sauvolaThreshold(gray), nil
+	default:
+		return nil, fmt.Errorf("unknown binarization method: %q", method)
+	}
+}
+
+// otsuThreshold picks a single global threshold that maximizes the
+// between-class variance of the foreground/background split, then applies
+// it across the whole image.
+func otsuThreshold(gray *image.Gray) *image.Gray {
+	bounds := gray.Bounds()
+
+	var histogram [256]int
+	total := 0
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			histogram[gray.GrayAt(x, y).Y]++
+			total++
+		}
+	}
+
+	sumAll := 0.0
+	for v, count := range histogram {
+		sumAll += float64(v * count)
+	}
+
+	sumBackground, weightBackground := 0.0, 0
+	bestVariance := -1.0
+	threshold := 128
+	for t := 0; t < 256; t++ {
+		weightBackground += histogram[t]
+		if weightBackground == 0 {
+			continue
+		}
+		weightForeground := total - weightBackground
+		if weightForeground == 0 {
+			break
+		}
+
+		sumBackground += float64(t * histogram[t])
+		meanBackground := sumBackground / float64(weightBackground)
+		meanForeground := (sumAll - sumBackground) / float64(weightForeground)
+		meanDiff := meanBackground - meanForeground
+
+		variance := float64(weightBackground) * float64(weightForeground) * meanDiff * meanDiff
+		if variance > bestVariance {
+			bestVariance = variance
+			threshold = t
+		}
+	}
+
+	return applyThreshold(gray, func(x, y int) float64 { return float64(threshold) })
+}
+
+// sauvolaThreshold computes a per-pixel threshold from the local mean and
+// standard deviation in a sauvolaWindow box, via integral images so each
+// box lookup is O(1) regardless of window size. It copes far better than
+// a global (Otsu) threshold with uneven scan lighting.
+func sauvolaThreshold(gray *image.Gray) *image.Gray {
+	bounds := gray.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+
+	sum := make([][]float64, h+1)
+	sumSq := make([][]float64, h+1)
+	for i := range sum {
+		sum[i] = make([]float64, w+1)
+		sumSq[i] = make([]float64, w+1)
+	}
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			v := float64(gray.GrayAt(bounds.Min.X+x, bounds.Min.Y+y).Y)
+			sum[y+1][x+1] = v + sum[y][x+1] + sum[y+1][x] - sum[y][x]
+			sumSq[y+1][x+1] = v*v + sumSq[y][x+1] + sumSq[y+1][x] - sumSq[y][x]
+		}
+	}
+
+	box := func(table [][]float64, x0, y0, x1, y1 int) float64 {
+		if x0 < 0 {
+			x0 = 0
+		}
+		if y0 < 0 {
+			y0 = 0
+		}
+		if x1 > w {
+			x1 = w
+		}
+		if y1 > h {
+			y1 = h
+		}
+		return table[y1][x1] - table[y0][x1] - table[y1][x0] + table[y0][x0]
+	}
+
+	half := sauvolaWindow / 2
+	return applyThreshold(gray, func(x, y int) float64 {
+		lx, ly := x-bounds.Min.X, y-bounds.Min.Y
+		x0, y0, x1, y1 := lx-half, ly-half, lx+half+1, ly+half+1
+		area := float64(clamp(x1, 0, w)-clamp(x0, 0, w)) * float64(clamp(y1, 0, h)-clamp(y0, 0, h))
+		if area <= 0 {
+			return 255
+		}
+
+		mean := box(sum, x0, y0, x1, y1) / area
+		meanSq := box(sumSq, x0, y0, x1, y1) / area
+		variance := meanSq - mean*mean
+		if variance < 0 {
+			variance = 0
+		}
+		stddev := math.Sqrt(variance)
+
+		return mean * (1 + sauvolaK*(stddev/sauvolaR-1))
+	})
+}
+
+func clamp(v, lo, hi int) int {
+	if v < lo {
+		return lo
+	}
+	if v > hi {
+		return hi
+	}
+	return v
+}
+
+// applyThreshold sets each pixel to black if its value is at or below
+// thresholdAt(x, y), white otherwise.
+func applyThreshold(gray *image.Gray, thresholdAt func(x, y int) float64) *image.Gray {
+	bounds := gray.Bounds()
+	out := image.NewGray(bounds)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			if float64(gray.GrayAt(x, y).Y) <= thresholdAt(x, y) {
+				out.SetGray(x, y, color.Gray{Y: 0})
+			} else {
+				out.SetGray(x, y, color.Gray{Y: 255})
+			}
+		}
+	}
+	return out
+}
@@ -0,0 +1,115 @@
+package Preprocess
+
+import (
+	"image"
+	"math"
+
+	xdraw "golang.org/x/image/draw"
+	"golang.org/x/image/math/f64"
+)
+
+const (
+	deskewMaxAngle  = 15.0 // degrees, either side of level
+	deskewAngleStep = 0.5  // degrees, the accumulator's vote resolution
+	deskewDetectDim = 300  // px, the long side the angle vote runs against
+)
+
+// deskew detects the dominant text-line angle within +/-deskewMaxAngle of
+// level and rotates gray to correct it, filling the newly exposed corners
+// with white background. A detected angle of exactly 0 is treated as
+// "nothing to fix" and returns gray unchanged.
+func deskew(gray *image.Gray) *image.Gray {
+	angle := detectSkewAngle(gray)
+	if angle == 0 {
+		return gray
+	}
+	return rotate(gray, -angle)
+}
+
+// detectSkewAngle runs a Hough-style accumulator: for each candidate angle
+// it buckets every dark pixel by its perpendicular distance (rho) from the
+// origin, then takes that angle's strongest single bucket as its vote.
+// The angle with the single strongest bucket overall is the one whose
+// rotation best aligns the page's dominant line structure (text rows,
+// table rules, etc.) to that orientation.
+func detectSkewAngle(gray *image.Gray) float64 {
+	small := downsampleForDetection(gray, deskewDetectDim)
+	bounds := small.Bounds()
+	diag := math.Hypot(float64(bounds.Dx()), float64(bounds.Dy()))
+	rhoBins := int(2*diag) + 1
+
+	bestAngle := 0.0
+	bestVotes := 0
+	for deg := -deskewMaxAngle; deg <= deskewMaxAngle; deg += deskewAngleStep {
+		theta := deg * math.Pi / 180
+		cosT, sinT := math.Cos(theta), math.Sin(theta)
+
+		votes := make([]int, rhoBins)
+		for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+			for x := bounds.Min.X; x < bounds.Max.X; x++ {
+				if small.GrayAt(x, y).Y > 127 {
+					continue // only dark (foreground) pixels vote
+				}
+				rho := float64(y)*cosT - float64(x)*sinT + diag
+				bucket := int(rho)
+				if bucket >= 0 && bucket < rhoBins {
+					votes[bucket]++
+				}
+			}
+		}
+
+		for _, v := range votes {
+			if v > bestVotes {
+				bestVotes = v
+				bestAngle = deg
+			}
+		}
+	}
+	return bestAngle
+}
+
+// downsampleForDetection shrinks gray so its longer side is at most
+// maxDim, bounding the accumulator's O(pixels * angles) cost regardless
+// of the source page's resolution. The detected angle is then applied to
+// the full-resolution image by rotate.
+func downsampleForDetection(gray *image.Gray, maxDim int) *image.Gray {
+	bounds := gray.Bounds()
+	longSide := bounds.Dx()
+	if bounds.Dy() > longSide {
+		longSide = bounds.Dy()
+	}
+	if longSide <= maxDim {
+		return gray
+	}
+
+	scale := float64(maxDim) / float64(longSide)
+	dstW := int(float64(bounds.Dx()) * scale)
+	dstH := int(float64(bounds.Dy()) * scale)
+	dst := image.NewGray(image.Rect(0, 0, dstW, dstH))
+	xdraw.BiLinear.Scale(dst, dst.Bounds(), gray, bounds, xdraw.Over, nil)
+	return dst
+}
+
+// rotate turns gray by degrees about its center, keeping the original
+// canvas size and filling any newly exposed corners with white
+// background.
+func rotate(gray *image.Gray, degrees float64) *image.Gray {
+	bounds := gray.Bounds()
+	theta := degrees * math.Pi / 180
+	cosT, sinT := math.Cos(theta), math.Sin(theta)
+	cx := float64(bounds.Min.X+bounds.Max.X) / 2
+	cy := float64(bounds.Min.Y+bounds.Max.Y) / 2
+
+	dst := image.NewGray(bounds)
+	for i := range dst.Pix {
+		dst.Pix[i] = 255
+	}
+
+	// Maps src-space (sx, sy) to dst-space: rotate by theta about (cx, cy).
+	s2d := f64.Aff3{
+		cosT, -sinT, cx - cosT*cx + sinT*cy,
+		sinT, cosT, cy - sinT*cx - cosT*cy,
+	}
+	xdraw.BiLinear.Transform(dst, s2d, gray, bounds, xdraw.Src, nil)
+	return dst
+}
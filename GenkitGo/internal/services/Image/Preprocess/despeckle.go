@@ -0,0 +1,57 @@
+package Preprocess
+
+import (
+	"image"
+	"image/color"
+)
+
+// despeckle removes isolated single-pixel noise via morphological opening
+// (erode then dilate) with a 3x3 structuring element, so stray dark
+// specks from scan noise don't survive into OCR as spurious characters.
+func despeckle(gray *image.Gray) *image.Gray {
+	return dilate3x3(erode3x3(gray))
+}
+
+// erode3x3 keeps a pixel dark only if every pixel in its 3x3 neighborhood
+// is dark too (treating out-of-bounds neighbors as background), shrinking
+// foreground regions and erasing anything smaller than the kernel.
+func erode3x3(gray *image.Gray) *image.Gray {
+	return morph3x3(gray, func(allDark, anyDark bool) bool { return allDark })
+}
+
+// dilate3x3 grows a pixel dark if any pixel in its 3x3 neighborhood is
+// dark, restoring the genuine strokes erode3x3 shrank.
+func dilate3x3(gray *image.Gray) *image.Gray {
+	return morph3x3(gray, func(allDark, anyDark bool) bool { return anyDark })
+}
+
+func morph3x3(gray *image.Gray, keep func(allDark, anyDark bool) bool) *image.Gray {
+	bounds := gray.Bounds()
+	out := image.NewGray(bounds)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			allDark, anyDark := true, false
+			for dy := -1; dy <= 1; dy++ {
+				for dx := -1; dx <= 1; dx++ {
+					nx, ny := x+dx, y+dy
+					if nx < bounds.Min.X || nx >= bounds.Max.X || ny < bounds.Min.Y || ny >= bounds.Max.Y {
+						allDark = false
+						continue
+					}
+					if gray.GrayAt(nx, ny).Y == 0 {
+						anyDark = true
+					} else {
+						allDark = false
+					}
+				}
+			}
+
+			if keep(allDark, anyDark) {
+				out.SetGray(x, y, color.Gray{Y: 0})
+			} else {
+				out.SetGray(x, y, color.Gray{Y: 255})
+			}
+		}
+	}
+	return out
+}
@@ -0,0 +1,198 @@
+// Package Preprocess cleans up a scanned or photographed page before it
+// reaches OCR: grayscale, adaptive threshold, morphological despeckle,
+// Hough-style deskew, and optional upscaling for small text.
+package Preprocess
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"image/draw"
+	_ "image/jpeg"
+	"image/png"
+	"os"
+	"path/filepath"
+
+	xdraw "golang.org/x/image/draw"
+)
+
+// Options controls which steps Execute applies, and with what strength.
+// The zero value runs no preprocessing at all.
+type Options struct {
+	Deskew bool
+
+	// Binarize names the adaptive-threshold method to apply: "otsu",
+	// "sauvola", or "" to skip binarization entirely.
+	Binarize string
+
+	Denoise bool
+
+	// Upscale is the scale factor applied last, e.g. 2 for 2x. Values <= 1
+	// skip upscaling.
+	Upscale float64
+}
+
+// Service runs the preprocessing pipeline. It is stateless - NewService
+// exists only to mirror the rest of the services package's construction
+// convention.
+type Service struct{}
+
+func NewService() *Service {
+	return &Service{}
+}
+
+// Execute runs src through Options' pipeline in a fixed order: grayscale
+// -> adaptive threshold -> morphological despeckle -> deskew -> upscale.
+// Every step is conditional on its Options flag, so a zero Options value
+// still returns a grayscale copy of src (the only unconditional step).
+func (s *Service) Execute(ctx context.Context, src image.Image, opts Options) (image.Image, error) {
+	gray := toGray(src)
+
+	if opts.Binarize != "" {
+		binarized, err := binarize(gray, opts.Binarize)
+		if err != nil {
+			return nil, err
+		}
+		gray = binarized
+	}
+
+	if opts.Denoise {
+		gray = despeckle(gray)
+	}
+
+	if opts.Deskew {
+		gray = deskew(gray)
+	}
+
+	var out image.Image = gray
+	if opts.Upscale > 1 {
+		out = upscale(gray, opts.Upscale)
+	}
+
+	return out, nil
+}
+
+// ProcessFile decodes the image at srcPath, runs Execute, and writes the
+// result to a new temp PNG. The caller must invoke the returned cleanup
+// func once it's done reading the file.
+func (s *Service) ProcessFile(ctx context.Context, srcPath string, opts Options) (string, func(), error) {
+	file, err := os.Open(srcPath)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to open image: %w", err)
+	}
+	src, _, err := image.Decode(file)
+	file.Close()
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to decode image: %w", err)
+	}
+
+	processed, err := s.Execute(ctx, src, opts)
+	if err != nil {
+		return "", nil, err
+	}
+
+	out, err := os.CreateTemp("", "preprocessed-*.png")
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to create output file: %w", err)
+	}
+
+	if err := png.Encode(out, processed); err != nil {
+		out.Close()
+		os.Remove(out.Name())
+		return "", nil, fmt.Errorf("failed to encode preprocessed image: %w", err)
+	}
+	out.Close()
+
+	cleanup := func() { os.Remove(out.Name()) }
+	return out.Name(), cleanup, nil
+}
+
+// CacheKey builds a short filename-safe fragment from opts so cached
+// preprocessed images don't get served stale after the options change.
+func CacheKey(opts Options) string {
+	deskew := 0
+	if opts.Deskew {
+		deskew = 1
+	}
+	denoise := 0
+	if opts.Denoise {
+		denoise = 1
+	}
+	binarize := opts.Binarize
+	if binarize == "" {
+		binarize = "none"
+	}
+	return fmt.Sprintf("d%d-b%s-n%d-u%.2f", deskew, binarize, denoise, opts.Upscale)
+}
+
+// ProcessFileCached behaves like ProcessFile, but first checks cachePath for
+// a previously preprocessed result and reuses it instead of recomputing. On
+// a cache miss it writes the result to cachePath so the next call with the
+// same opts is cheap. Unlike ProcessFile's temp file, the returned path is
+// cachePath itself and no cleanup func is returned - the caller doesn't own
+// cachePath's lifetime.
+func (s *Service) ProcessFileCached(ctx context.Context, srcPath, cachePath string, opts Options) (string, error) {
+	if _, err := os.Stat(cachePath); err == nil {
+		return cachePath, nil
+	}
+
+	file, err := os.Open(srcPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to open image: %w", err)
+	}
+	src, _, err := image.Decode(file)
+	file.Close()
+	if err != nil {
+		return "", fmt.Errorf("failed to decode image: %w", err)
+	}
+
+	processed, err := s.Execute(ctx, src, opts)
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(cachePath), 0o755); err != nil {
+		return "", fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	out, err := os.Create(cachePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to create cache file: %w", err)
+	}
+	if err := png.Encode(out, processed); err != nil {
+		out.Close()
+		os.Remove(cachePath)
+		return "", fmt.Errorf("failed to encode preprocessed image: %w", err)
+	}
+	out.Close()
+
+	return cachePath, nil
+}
+
+func toGray(src image.Image) *image.Gray {
+	if gray, ok := src.(*image.Gray); ok {
+		return gray
+	}
+	bounds := src.Bounds()
+	gray := image.NewGray(bounds)
+	draw.Draw(gray, bounds, src, bounds.Min, draw.Src)
+	return gray
+}
+
+// upscale scales img up by factor using bilinear interpolation, the same
+// resampling CropBlocks uses for its downscaled thumbnails.
+func upscale(img image.Image, factor float64) image.Image {
+	bounds := img.Bounds()
+	dstW := int(float64(bounds.Dx()) * factor)
+	dstH := int(float64(bounds.Dy()) * factor)
+	if dstW < 1 {
+		dstW = 1
+	}
+	if dstH < 1 {
+		dstH = 1
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dstW, dstH))
+	xdraw.BiLinear.Scale(dst, dst.Bounds(), img, bounds, xdraw.Over, nil)
+	return dst
+}
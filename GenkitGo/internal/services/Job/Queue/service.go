@@ -0,0 +1,347 @@
+package Queue
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/plobin/genkitgo/internal/models"
+	"github.com/plobin/genkitgo/internal/services/File/Storage"
+	"github.com/plobin/genkitgo/internal/services/PDF/ProcessPDF"
+)
+
+const queueDepth = 256 // bounds how many jobs can sit queued before Enqueue reports backpressure
+
+// jobEntry is the in-memory handle for a Job: its current state plus the
+// cancel func and SSE subscribers, none of which are persisted.
+type jobEntry struct {
+	job    Job
+	cancel context.CancelFunc
+	subs   map[chan ProgressEvent]struct{}
+}
+
+// Service runs process-pdf jobs on a bounded worker pool, persisting every
+// transition under jobsDir so queued/running jobs can be recovered after a
+// restart. Construct one with NewService and let main.go own its lifetime.
+type Service struct {
+	mu             sync.Mutex
+	jobsDir        string
+	jobs           map[string]*jobEntry
+	work           chan *jobEntry
+	pdfService     *ProcessPDF.Service
+	storageService *Storage.Service
+}
+
+// NewService creates a job queue backed by jobsDir and starts workers
+// worker goroutines draining it. Any job left queued or running in
+// jobsDir from a previous run is recovered and re-enqueued as queued.
+func NewService(workers int, jobsDir string, pdfService *ProcessPDF.Service, storageService *Storage.Service) *Service {
+	if workers <= 0 {
+		workers = 4
+	}
+	os.MkdirAll(jobsDir, 0755)
+
+	s := &Service{
+		jobsDir:        jobsDir,
+		jobs:           make(map[string]*jobEntry),
+		work:           make(chan *jobEntry, queueDepth),
+		pdfService:     pdfService,
+		storageService: storageService,
+	}
+
+	s.recoverJobs()
+
+	for i := 0; i < workers; i++ {
+		go s.runWorker()
+	}
+
+	return s
+}
+
+// Enqueue spools requestID bookkeeping for pdfPath and schedules it for
+// background OCR against the named backend (empty uses the PDF service's
+// configured default). The caller (the /api/process-pdf handler) owns
+// pdfPath until the job finishes - the worker removes it once processing
+// ends.
+func (s *Service) Enqueue(pdfPath string, backend string) (*Job, error) {
+	info, err := os.Stat(pdfPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat PDF: %w", err)
+	}
+
+	requestID, err := s.storageService.CreateRequest(context.Background(), filepath.Base(pdfPath), models.RequestTypePDF, info.Size(), 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	now := time.Now()
+	entry := &jobEntry{
+		job: Job{
+			ID:        uuid.New().String(),
+			PDFPath:   pdfPath,
+			RequestID: requestID,
+			Backend:   backend,
+			Status:    StatusQueued,
+			CreatedAt: now,
+			UpdatedAt: now,
+		},
+		subs: make(map[chan ProgressEvent]struct{}),
+	}
+
+	s.mu.Lock()
+	s.jobs[entry.job.ID] = entry
+	s.mu.Unlock()
+
+	if err := s.persistJob(entry.job); err != nil {
+		log.Printf("Queue: failed to persist job %s: %v", entry.job.ID, err)
+	}
+
+	select {
+	case s.work <- entry:
+	default:
+		return nil, fmt.Errorf("job queue is full, try again later")
+	}
+
+	jobCopy := entry.job
+	return &jobCopy, nil
+}
+
+// Get returns the current state of a job.
+func (s *Service) Get(id string) (*Job, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.jobs[id]
+	if !ok {
+		return nil, fmt.Errorf("job not found: %s", id)
+	}
+	jobCopy := entry.job
+	return &jobCopy, nil
+}
+
+// Cancel requests cancellation of a running (or still-queued) job via its
+// stored context.CancelFunc. Cancelling a queued job takes effect as soon
+// as a worker picks it up.
+func (s *Service) Cancel(id string) error {
+	s.mu.Lock()
+	entry, ok := s.jobs[id]
+	s.mu.Unlock()
+	if !ok {
+		return fmt.Errorf("job not found: %s", id)
+	}
+
+	s.mu.Lock()
+	cancel := entry.cancel
+	s.mu.Unlock()
+
+	if cancel != nil {
+		cancel()
+		return nil
+	}
+
+	// Not running yet: mark it failed up front so a worker that dequeues
+	// it afterwards skips straight past without doing any OCR work.
+	s.updateJob(entry, func(j *Job) {
+		if j.Status == StatusQueued {
+			j.Status = StatusFailed
+			j.Error = "cancelled before it started running"
+		}
+	})
+	return nil
+}
+
+// Subscribe registers for progress events on a job. The returned func must
+// be called to unsubscribe and release the channel.
+func (s *Service) Subscribe(id string) (<-chan ProgressEvent, func(), error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.jobs[id]
+	if !ok {
+		return nil, nil, fmt.Errorf("job not found: %s", id)
+	}
+
+	ch := make(chan ProgressEvent, 16)
+	entry.subs[ch] = struct{}{}
+
+	unsubscribe := func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if _, ok := entry.subs[ch]; ok {
+			delete(entry.subs, ch)
+			close(ch)
+		}
+	}
+	return ch, unsubscribe, nil
+}
+
+// runWorker drains work until the queue is closed (never, in practice -
+// the process lives as long as cmd/server does).
+func (s *Service) runWorker() {
+	for entry := range s.work {
+		s.process(entry)
+	}
+}
+
+func (s *Service) process(entry *jobEntry) {
+	s.mu.Lock()
+	alreadyFailed := entry.job.Status == StatusFailed
+	s.mu.Unlock()
+	if alreadyFailed {
+		return // cancelled while still queued
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.mu.Lock()
+	entry.cancel = cancel
+	s.mu.Unlock()
+
+	s.updateJob(entry, func(j *Job) {
+		j.Status = StatusRunning
+		started := time.Now()
+		j.StartedAt = &started
+	})
+
+	_, err := s.pdfService.Execute(ctx, entry.job.PDFPath, entry.job.RequestID,
+		ProcessPDF.WithBackend(entry.job.Backend),
+		ProcessPDF.WithProgress(func(pageDone, pageTotal int) {
+			s.updateJob(entry, func(j *Job) {
+				j.PageDone = pageDone
+				j.PageTotal = pageTotal
+			})
+			s.persistProgress(ctx, entry.job.RequestID, pageDone, pageTotal)
+		}),
+	)
+
+	s.updateJob(entry, func(j *Job) {
+		finished := time.Now()
+		j.FinishedAt = &finished
+		if err != nil {
+			j.Status = StatusFailed
+			j.Error = err.Error()
+		} else {
+			j.Status = StatusDone
+		}
+	})
+
+	if metadata, metaErr := s.storageService.GetMetadata(ctx, entry.job.RequestID); metaErr == nil {
+		metadata.TotalPages = entry.job.PageTotal
+		metadata.UpdatedAt = time.Now()
+		if err != nil {
+			metadata.Status = models.RequestStatusFailed
+			metadata.ErrorMessage = err.Error()
+		} else {
+			metadata.Status = models.RequestStatusCompleted
+			metadata.Progress = 100
+		}
+		if err := s.storageService.SaveMetadata(ctx, entry.job.RequestID, metadata); err != nil {
+			log.Printf("Queue: failed to update request metadata for %s: %v", entry.job.RequestID, err)
+		}
+	}
+
+	os.Remove(entry.job.PDFPath)
+}
+
+// persistProgress mirrors a page-completion callback into
+// RequestMetadata.Progress so GET /api/requests/{id}/status reports
+// consistent progress whether a request went through this in-process queue
+// or the Redis-backed internal/jobs path.
+func (s *Service) persistProgress(ctx context.Context, requestID string, pageDone, pageTotal int) {
+	metadata, err := s.storageService.GetMetadata(ctx, requestID)
+	if err != nil {
+		log.Printf("Queue: failed to load metadata for %s: %v", requestID, err)
+		return
+	}
+	if pageTotal > 0 {
+		metadata.Progress = pageDone * 100 / pageTotal
+	}
+	metadata.UpdatedAt = time.Now()
+	if err := s.storageService.SaveMetadata(ctx, requestID, metadata); err != nil {
+		log.Printf("Queue: failed to save progress for %s: %v", requestID, err)
+	}
+}
+
+// updateJob mutates a job under lock, publishes the resulting state to any
+// SSE subscribers, and persists it to disk.
+func (s *Service) updateJob(entry *jobEntry, mutate func(*Job)) {
+	s.mu.Lock()
+	mutate(&entry.job)
+	entry.job.UpdatedAt = time.Now()
+	jobCopy := entry.job
+
+	event := ProgressEvent{
+		JobID:     jobCopy.ID,
+		Status:    jobCopy.Status,
+		PageDone:  jobCopy.PageDone,
+		PageTotal: jobCopy.PageTotal,
+		Error:     jobCopy.Error,
+	}
+	for ch := range entry.subs {
+		select {
+		case ch <- event:
+		default: // a slow subscriber shouldn't stall job processing
+		}
+	}
+	s.mu.Unlock()
+
+	if err := s.persistJob(jobCopy); err != nil {
+		log.Printf("Queue: failed to persist job %s: %v", jobCopy.ID, err)
+	}
+}
+
+func (s *Service) persistJob(job Job) error {
+	data, err := json.MarshalIndent(job, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal job: %w", err)
+	}
+	return os.WriteFile(filepath.Join(s.jobsDir, job.ID+".json"), data, 0644)
+}
+
+// recoverJobs reloads every persisted job still marked queued or running -
+// an orphan from a previous process that didn't shut down cleanly - and
+// re-enqueues it as queued.
+func (s *Service) recoverJobs() {
+	entries, err := os.ReadDir(s.jobsDir)
+	if err != nil {
+		return
+	}
+
+	for _, e := range entries {
+		if e.IsDir() || filepath.Ext(e.Name()) != ".json" {
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join(s.jobsDir, e.Name()))
+		if err != nil {
+			continue
+		}
+
+		var job Job
+		if err := json.Unmarshal(data, &job); err != nil {
+			continue
+		}
+		if job.Status != StatusQueued && job.Status != StatusRunning {
+			continue
+		}
+		if _, err := os.Stat(job.PDFPath); err != nil {
+			continue // the spooled PDF didn't survive the restart
+		}
+
+		job.Status = StatusQueued
+		job.StartedAt = nil
+		entry := &jobEntry{job: job, subs: make(map[chan ProgressEvent]struct{})}
+		s.jobs[job.ID] = entry
+
+		select {
+		case s.work <- entry:
+		default:
+			log.Printf("Queue: recovered job %s but the queue is full; it stays queued until the next restart", job.ID)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package Queue
+
+import "time"
+
+// Status is the lifecycle state of a queued PDF OCR job.
+type Status string
+
+const (
+	StatusQueued  Status = "queued"
+	StatusRunning Status = "running"
+	StatusDone    Status = "done"
+	StatusFailed  Status = "failed"
+)
+
+// Job is the persisted, JSON-serializable state of one process-pdf run.
+// It is written to OutputDir/jobs/{id}.json on every transition so a
+// restarted server can recover jobs that were still queued or running.
+type Job struct {
+	ID         string     `json:"id"`
+	PDFPath    string     `json:"pdf_path"`
+	RequestID  string     `json:"request_id"`
+	Backend    string     `json:"backend,omitempty"`
+	Status     Status     `json:"status"`
+	PageDone   int        `json:"page_done"`
+	PageTotal  int        `json:"page_total"`
+	Error      string     `json:"error,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+	StartedAt  *time.Time `json:"started_at,omitempty"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+}
+
+// ETA extrapolates the remaining time from the pages completed so far.
+// It returns 0 when the job isn't running yet or hasn't made enough
+// progress to extrapolate from.
+func (j Job) ETA() time.Duration {
+	if j.Status != StatusRunning || j.StartedAt == nil || j.PageDone == 0 || j.PageTotal <= j.PageDone {
+		return 0
+	}
+	perPage := time.Since(*j.StartedAt) / time.Duration(j.PageDone)
+	return perPage * time.Duration(j.PageTotal-j.PageDone)
+}
+
+// ProgressEvent is pushed to SSE subscribers as a job advances.
+type ProgressEvent struct {
+	JobID     string `json:"job_id"`
+	Status    Status `json:"status"`
+	PageDone  int    `json:"page_done"`
+	PageTotal int    `json:"page_total"`
+	Error     string `json:"error,omitempty"`
+}
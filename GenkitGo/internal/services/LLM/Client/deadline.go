@@ -0,0 +1,62 @@
+package Client
+
+import (
+	"sync"
+	"time"
+)
+
+// deadline is a resettable, channel-based timeout: set(t) arms or disarms
+// it and c() returns the channel to select on, mirroring net.Conn's
+// SetReadDeadline/SetWriteDeadline. chatCompletion/ChatCompletionStream each
+// create their own deadline per call, resolved from that call's
+// WithReadDeadline/WithWriteDeadline (types_client_options.go) if set, or
+// else LLMClient.SetReadDeadline/SetWriteDeadline's current default
+// (resolveDeadline in service.go) - so one call's deadline can never abort
+// an unrelated concurrent call sharing the same LLMClient.
+type deadline struct {
+	mu       sync.Mutex
+	timer    *time.Timer
+	cancelCh chan struct{}
+}
+
+// newDeadline returns a deadline with nothing armed: c() never closes until
+// set is called with a non-zero time.
+func newDeadline() *deadline {
+	return &deadline{cancelCh: make(chan struct{})}
+}
+
+// set arms the deadline for t, replacing whatever was armed before. A zero
+// t disarms it (the channel returned by c() simply never closes again). A
+// t already in the past closes the channel immediately. Safe to call while
+// another goroutine is selecting on c().
+func (d *deadline) set(t time.Time) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.timer != nil && !d.timer.Stop() {
+		// The timer already fired and closed cancelCh - start a fresh one
+		// so c() reflects the new deadline instead of reporting expired.
+		d.cancelCh = make(chan struct{})
+	}
+	d.timer = nil
+
+	if t.IsZero() {
+		return
+	}
+
+	dur := time.Until(t)
+	if dur <= 0 {
+		close(d.cancelCh)
+		return
+	}
+
+	ch := d.cancelCh
+	d.timer = time.AfterFunc(dur, func() { close(ch) })
+}
+
+// c returns the channel that closes once the deadline passes.
+func (d *deadline) c() <-chan struct{} {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.cancelCh
+}
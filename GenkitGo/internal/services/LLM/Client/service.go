@@ -1,20 +1,27 @@
 package Client
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
+	"sync"
 	"time"
+
+	"github.com/plobin/genkitgo/internal/observability"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
 )
 
 // LLMModel represents available LLM models
 type LLMModel string
 
 const (
-	ModelBoto          LLMModel = "boto"            // Qwen3-Omni-30B-A3B-Instruct
+	ModelBoto          LLMModel = "boto" // Qwen3-Omni-30B-A3B-Instruct
 	ModelGPT4          LLMModel = "gpt-4"
 	ModelGPT35Turbo    LLMModel = "gpt-3.5-turbo"
 	ModelClaude3Sonnet LLMModel = "claude-3-sonnet"
@@ -23,17 +30,25 @@ const (
 
 // Message represents a chat message
 type Message struct {
-	Role    string `json:"role"`    // "system", "user", or "assistant"
+	Role    string `json:"role"` // "system", "user", or "assistant"
 	Content string `json:"content"`
 }
 
+// ResponseFormat asks the API for a specific response shape. Type
+// "json_object" requests the OpenAI-compatible JSON mode, where the model
+// is constrained to emit a single parseable JSON object instead of free text.
+type ResponseFormat struct {
+	Type string `json:"type"`
+}
+
 // LLMRequest represents the request to LLM API
 type LLMRequest struct {
-	Model       string    `json:"model"`
-	Messages    []Message `json:"messages"`
-	Temperature float64   `json:"temperature"`
-	MaxTokens   int       `json:"max_tokens,omitempty"`
-	Stream      bool      `json:"stream"`
+	Model          string          `json:"model"`
+	Messages       []Message       `json:"messages"`
+	Temperature    float64         `json:"temperature"`
+	MaxTokens      int             `json:"max_tokens,omitempty"`
+	Stream         bool            `json:"stream"`
+	ResponseFormat *ResponseFormat `json:"response_format,omitempty"`
 }
 
 // LLMResponse represents the response from LLM API
@@ -57,15 +72,44 @@ type LLMResponse struct {
 	} `json:"usage"`
 }
 
+// StreamChunk is one incremental piece of a streamed chat completion, sent
+// on the channel ChatCompletionStream returns. FinishReason is set (and
+// Delta empty) on the final chunk before the channel closes; Err is set
+// instead if the stream failed partway through, also as the final value.
+type StreamChunk struct {
+	Delta        string
+	FinishReason string
+	Err          error
+}
+
+// llmStreamResponse is one `data: {...}` SSE frame from a streamed
+// completion - the same envelope as LLMResponse, but each choice carries an
+// incremental Delta instead of a complete Message.
+type llmStreamResponse struct {
+	Choices []struct {
+		Delta struct {
+			Content string `json:"content"`
+		} `json:"delta"`
+		FinishReason string `json:"finish_reason"`
+	} `json:"choices"`
+}
+
 // LLMClient is a client for ai.gupsa.net/v1 LLM API
 type LLMClient struct {
 	baseURL string
 	apiKey  string
 	model   string
 	client  *http.Client
+
+	mu                   sync.RWMutex
+	defaultReadDeadline  time.Time
+	defaultWriteDeadline time.Time
 }
 
-// NewLLMClient creates a new LLM client
+// NewLLMClient creates a new LLM client. The underlying http.Client has no
+// Timeout: callers bound a call's lifetime via ctx and/or
+// WithReadDeadline/WithWriteDeadline instead, so a deadline longer than some
+// fixed http.Client.Timeout isn't silently cut short.
 func NewLLMClient(baseURL, apiKey, model string) *LLMClient {
 	if model == "" {
 		model = string(ModelBoto)
@@ -75,21 +119,117 @@ func NewLLMClient(baseURL, apiKey, model string) *LLMClient {
 		baseURL: baseURL,
 		apiKey:  apiKey,
 		model:   model,
-		client: &http.Client{
-			Timeout: 60 * time.Second,
-		},
+		client:  &http.Client{},
 	}
 }
 
+// SetReadDeadline sets the read deadline applied to every future call that
+// doesn't arm its own via WithReadDeadline. A zero Time clears it. Safe to
+// call while other calls are in flight - it only takes effect for calls
+// that haven't yet resolved their own deadline, never aborting one already
+// running.
+func (c *LLMClient) SetReadDeadline(t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.defaultReadDeadline = t
+}
+
+// SetWriteDeadline sets the write deadline applied to every future call
+// that doesn't arm its own via WithWriteDeadline. A zero Time clears it.
+func (c *LLMClient) SetWriteDeadline(t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.defaultWriteDeadline = t
+}
+
+func (c *LLMClient) readDeadlineDefault() time.Time {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.defaultReadDeadline
+}
+
+func (c *LLMClient) writeDeadlineDefault() time.Time {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.defaultWriteDeadline
+}
+
+// resolveDeadline prefers a call's own deadline over the client's default,
+// so a per-call WithReadDeadline/WithWriteDeadline override never fights
+// over a deadline set on the shared LLMClient.
+func resolveDeadline(perCall, clientDefault time.Time) time.Time {
+	if !perCall.IsZero() {
+		return perCall
+	}
+	return clientDefault
+}
+
 // ChatCompletion sends a chat completion request
-func (c *LLMClient) ChatCompletion(ctx context.Context, messages []Message, temperature float64) (*LLMResponse, error) {
+func (c *LLMClient) ChatCompletion(ctx context.Context, messages []Message, temperature float64, opts ...ChatCompletionOption) (*LLMResponse, error) {
+	return c.chatCompletion(ctx, messages, temperature, 0, nil, opts...)
+}
+
+// ChatCompletionWithLimit is ChatCompletion with a per-call cap on response
+// tokens, so callers processing many pages (block correction, field
+// extraction) can bound spend per page instead of per request.
+func (c *LLMClient) ChatCompletionWithLimit(ctx context.Context, messages []Message, temperature float64, maxTokens int, opts ...ChatCompletionOption) (*LLMResponse, error) {
+	return c.chatCompletion(ctx, messages, temperature, maxTokens, nil, opts...)
+}
+
+// ChatCompletionJSON is ChatCompletionWithLimit, additionally requesting the
+// API's JSON response mode so the caller gets back a single parseable JSON
+// object instead of having to scrape one out of free text.
+func (c *LLMClient) ChatCompletionJSON(ctx context.Context, messages []Message, temperature float64, maxTokens int, opts ...ChatCompletionOption) (*LLMResponse, error) {
+	return c.chatCompletion(ctx, messages, temperature, maxTokens, &ResponseFormat{Type: "json_object"}, opts...)
+}
+
+// chatDoResult is the outcome of a background c.client.Do call, delivered
+// on a channel so chatCompletion/ChatCompletionStream can select on it
+// alongside ctx.Done() and the read/write deadlines instead of blocking on
+// it directly.
+type chatDoResult struct {
+	resp *http.Response
+	err  error
+}
+
+// drainChatDo waits for a Do call that lost the select (ctx/deadline fired
+// first) and closes its response body, if any, so the connection isn't
+// leaked just because the caller stopped waiting on it.
+func drainChatDo(resultCh <-chan chatDoResult) {
+	r := <-resultCh
+	if r.resp != nil {
+		r.resp.Body.Close()
+	}
+}
+
+func (c *LLMClient) chatCompletion(ctx context.Context, messages []Message, temperature float64, maxTokens int, format *ResponseFormat, opts ...ChatCompletionOption) (_ *LLMResponse, err error) {
+	ctx, span := observability.Tracer("llm").Start(ctx, "LLMClient.ChatCompletion")
+	span.SetAttributes(attribute.String("model", c.model))
+	start := time.Now()
+	defer func() {
+		observability.RecordLLMRequest(c.model, time.Since(start))
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	o := resolveChatCompletionOptions(opts...)
+	readDeadline := newDeadline()
+	readDeadline.set(resolveDeadline(o.ReadDeadline, c.readDeadlineDefault()))
+	writeDeadline := newDeadline()
+	writeDeadline.set(resolveDeadline(o.WriteDeadline, c.writeDeadlineDefault()))
+
 	url := fmt.Sprintf("%s/chat/completions", c.baseURL)
 
 	reqBody := LLMRequest{
-		Model:       c.model,
-		Messages:    messages,
-		Temperature: temperature,
-		Stream:      false,
+		Model:          c.model,
+		Messages:       messages,
+		Temperature:    temperature,
+		MaxTokens:      maxTokens,
+		Stream:         false,
+		ResponseFormat: format,
 	}
 
 	jsonData, err := json.Marshal(reqBody)
@@ -97,7 +237,10 @@ func (c *LLMClient) ChatCompletion(ctx context.Context, messages []Message, temp
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonData))
+	reqCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(reqCtx, "POST", url, bytes.NewBuffer(jsonData))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -107,9 +250,28 @@ func (c *LLMClient) ChatCompletion(ctx context.Context, messages []Message, temp
 		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.apiKey))
 	}
 
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to send request: %w", err)
+	resultCh := make(chan chatDoResult, 1)
+	go func() {
+		resp, err := c.client.Do(req)
+		resultCh <- chatDoResult{resp, err}
+	}()
+
+	var resp *http.Response
+	select {
+	case <-ctx.Done():
+		go drainChatDo(resultCh)
+		return nil, ctx.Err()
+	case <-readDeadline.c():
+		go drainChatDo(resultCh)
+		return nil, fmt.Errorf("chat completion aborted: read deadline exceeded")
+	case <-writeDeadline.c():
+		go drainChatDo(resultCh)
+		return nil, fmt.Errorf("chat completion aborted: write deadline exceeded")
+	case r := <-resultCh:
+		if r.err != nil {
+			return nil, fmt.Errorf("failed to send request: %w", r.err)
+		}
+		resp = r.resp
 	}
 	defer resp.Body.Close()
 
@@ -128,17 +290,186 @@ func (c *LLMClient) ChatCompletion(ctx context.Context, messages []Message, temp
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
+	span.SetAttributes(
+		attribute.Int("prompt_tokens", llmResp.Usage.PromptTokens),
+		attribute.Int("completion_tokens", llmResp.Usage.CompletionTokens),
+	)
+
 	return &llmResp, nil
 }
 
+// ChatCompletionStream is ChatCompletion with Stream: true: it POSTs the
+// request, then reads the response body as Server-Sent Events, decoding
+// each `data: {...}` frame into a StreamChunk delivered on the returned
+// channel as it arrives, rather than waiting for the whole completion. The
+// channel closes when the server sends `data: [DONE]`, the body ends, or
+// ctx is cancelled; a mid-stream failure is delivered as a final chunk with
+// Err set before the channel closes.
+func (c *LLMClient) ChatCompletionStream(ctx context.Context, messages []Message, temperature float64, opts ...ChatCompletionOption) (<-chan StreamChunk, error) {
+	o := resolveChatCompletionOptions(opts...)
+	readDeadline := newDeadline()
+	readDeadline.set(resolveDeadline(o.ReadDeadline, c.readDeadlineDefault()))
+	writeDeadline := newDeadline()
+	writeDeadline.set(resolveDeadline(o.WriteDeadline, c.writeDeadlineDefault()))
+
+	url := fmt.Sprintf("%s/chat/completions", c.baseURL)
+
+	reqBody := LLMRequest{
+		Model:       c.model,
+		Messages:    messages,
+		Temperature: temperature,
+		Stream:      true,
+	}
+
+	jsonData, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	reqCtx, cancel := context.WithCancel(ctx)
+
+	req, err := http.NewRequestWithContext(reqCtx, "POST", url, bytes.NewBuffer(jsonData))
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "text/event-stream")
+	if c.apiKey != "" {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.apiKey))
+	}
+
+	resultCh := make(chan chatDoResult, 1)
+	go func() {
+		resp, err := c.client.Do(req)
+		resultCh <- chatDoResult{resp, err}
+	}()
+
+	var resp *http.Response
+	select {
+	case <-ctx.Done():
+		cancel()
+		go drainChatDo(resultCh)
+		return nil, ctx.Err()
+	case <-readDeadline.c():
+		cancel()
+		go drainChatDo(resultCh)
+		return nil, fmt.Errorf("chat completion stream aborted: read deadline exceeded")
+	case <-writeDeadline.c():
+		cancel()
+		go drainChatDo(resultCh)
+		return nil, fmt.Errorf("chat completion stream aborted: write deadline exceeded")
+	case r := <-resultCh:
+		if r.err != nil {
+			cancel()
+			return nil, fmt.Errorf("failed to send request: %w", r.err)
+		}
+		resp = r.resp
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		cancel()
+		return nil, fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(body))
+	}
+
+	chunks := make(chan StreamChunk)
+	go func() {
+		defer close(chunks)
+		defer cancel()
+		defer resp.Body.Close()
+
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			select {
+			case <-ctx.Done():
+				return
+			case <-readDeadline.c():
+				chunks <- StreamChunk{Err: fmt.Errorf("chat completion stream aborted: read deadline exceeded")}
+				return
+			default:
+			}
+
+			line := strings.TrimSpace(scanner.Text())
+			if line == "" || !strings.HasPrefix(line, "data:") {
+				continue
+			}
+
+			data := strings.TrimSpace(strings.TrimPrefix(line, "data:"))
+			if data == "[DONE]" {
+				return
+			}
+
+			var frame llmStreamResponse
+			if err := json.Unmarshal([]byte(data), &frame); err != nil {
+				chunks <- StreamChunk{Err: fmt.Errorf("failed to parse stream frame: %w", err)}
+				return
+			}
+			if len(frame.Choices) == 0 {
+				continue
+			}
+
+			choice := frame.Choices[0]
+			chunks <- StreamChunk{Delta: choice.Delta.Content, FinishReason: choice.FinishReason}
+		}
+
+		if err := scanner.Err(); err != nil {
+			chunks <- StreamChunk{Err: fmt.Errorf("failed to read stream: %w", err)}
+		}
+	}()
+
+	return chunks, nil
+}
+
 // AnalyzeText is a convenience method for text analysis
 func (c *LLMClient) AnalyzeText(ctx context.Context, text, prompt string, temperature float64) (string, error) {
+	return c.AnalyzeTextWithLimit(ctx, text, prompt, temperature, 0)
+}
+
+// AnalyzeTextWithLimit is AnalyzeText with a per-call cap on response tokens.
+func (c *LLMClient) AnalyzeTextWithLimit(ctx context.Context, text, prompt string, temperature float64, maxTokens int) (string, error) {
+	messages := []Message{
+		{Role: "system", Content: prompt},
+		{Role: "user", Content: text},
+	}
+
+	resp, err := c.ChatCompletionWithLimit(ctx, messages, temperature, maxTokens)
+	if err != nil {
+		return "", err
+	}
+
+	if len(resp.Choices) == 0 {
+		return "", fmt.Errorf("no response from LLM")
+	}
+
+	return resp.Choices[0].Message.Content, nil
+}
+
+// AnalyzeTextStream is AnalyzeText, streamed: it delivers the response's
+// text incrementally via ChatCompletionStream instead of blocking for the
+// whole completion - for large documents, so a caller (e.g. an OCR analyze
+// handler) can flush partial output to its own client as it arrives rather
+// than waiting out the full synchronous call.
+func (c *LLMClient) AnalyzeTextStream(ctx context.Context, text, prompt string, temperature float64) (<-chan StreamChunk, error) {
+	messages := []Message{
+		{Role: "system", Content: prompt},
+		{Role: "user", Content: text},
+	}
+	return c.ChatCompletionStream(ctx, messages, temperature)
+}
+
+// AnalyzeTextJSON is AnalyzeTextWithLimit, requesting the API's JSON
+// response mode for callers that need a parseable object back (field
+// extraction) rather than free-form text.
+func (c *LLMClient) AnalyzeTextJSON(ctx context.Context, text, prompt string, temperature float64, maxTokens int) (string, error) {
 	messages := []Message{
 		{Role: "system", Content: prompt},
 		{Role: "user", Content: text},
 	}
 
-	resp, err := c.ChatCompletion(ctx, messages, temperature)
+	resp, err := c.ChatCompletionJSON(ctx, messages, temperature, maxTokens)
 	if err != nil {
 		return "", err
 	}
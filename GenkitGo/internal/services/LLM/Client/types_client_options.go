@@ -0,0 +1,38 @@
+package Client
+
+import "time"
+
+// ChatCompletionOptions collects per-call overrides for a single
+// ChatCompletion/ChatCompletionStream call. A zero ReadDeadline/WriteDeadline
+// falls back to LLMClient's own SetReadDeadline/SetWriteDeadline default
+// (see resolveDeadline in service.go); either way the deadline is resolved
+// once, for this call only, so it can never race another concurrent call
+// sharing the same LLMClient.
+type ChatCompletionOptions struct {
+	ReadDeadline  time.Time
+	WriteDeadline time.Time
+}
+
+// ChatCompletionOption mutates a ChatCompletionOptions being built up.
+type ChatCompletionOption func(*ChatCompletionOptions)
+
+// WithReadDeadline arms a read deadline for this call only, overriding
+// whatever LLMClient.SetReadDeadline has set as the default.
+func WithReadDeadline(t time.Time) ChatCompletionOption {
+	return func(o *ChatCompletionOptions) { o.ReadDeadline = t }
+}
+
+// WithWriteDeadline arms a write deadline for this call only, overriding
+// whatever LLMClient.SetWriteDeadline has set as the default.
+func WithWriteDeadline(t time.Time) ChatCompletionOption {
+	return func(o *ChatCompletionOptions) { o.WriteDeadline = t }
+}
+
+// resolveChatCompletionOptions applies opts over the zero value.
+func resolveChatCompletionOptions(opts ...ChatCompletionOption) ChatCompletionOptions {
+	var o ChatCompletionOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
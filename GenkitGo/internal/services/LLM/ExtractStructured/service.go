@@ -0,0 +1,159 @@
+// Package ExtractStructured asks the LLM to populate a caller-supplied JSON
+// Schema from a request's OCR text, page by page, via the LLM API's JSON
+// response mode (see Client.ChatCompletionJSON) - the closest thing the
+// backing ai.gupsa.net API offers to function-calling/structured output.
+package ExtractStructured
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/plobin/genkitgo/internal/models"
+	"github.com/plobin/genkitgo/internal/services/LLM/Client"
+	"github.com/plobin/genkitgo/internal/services/OCR/Store"
+)
+
+// defaultMaxTokensPerPage bounds a single page's extraction call when the
+// caller doesn't set Options.MaxTokensPerPage.
+const defaultMaxTokensPerPage = 1024
+
+const systemPromptTemplate = `You extract structured data from OCR'd document text. Every line of input is prefixed with its block ID in brackets, like "[42] Invoice Number: INV-1001".
+
+Given this JSON Schema:
+%s
+
+Respond with ONLY a JSON object of this shape, and nothing else:
+{"fields": {"<field name>": {"value": <the extracted value, matching the schema type, or null if not present on this page>, "confidence": <0.0-1.0>, "source_block_ids": [<block IDs the value was read from>]}}}
+
+Only include a field if you found evidence for it in the text below. Omit fields you didn't find.`
+
+// ProgressEvent is pushed to Options.Progress as extraction proceeds, one
+// per page, so an SSE handler can stream progress for multi-page documents.
+type ProgressEvent struct {
+	PageNumber int `json:"page_number"`
+	PageTotal  int `json:"page_total"`
+}
+
+// Options controls a single Execute call.
+type Options struct {
+	// MaxTokensPerPage caps the LLM response length for each page's
+	// extraction call. <= 0 uses defaultMaxTokensPerPage.
+	MaxTokensPerPage int
+
+	// Progress, if set, is called after every page finishes extraction.
+	Progress func(ProgressEvent)
+}
+
+// Service populates a JSON Schema from a request's stored OCR blocks.
+type Service struct {
+	llmClient *Client.LLMClient
+	store     *Store.Service
+}
+
+func NewService(llmClient *Client.LLMClient, store *Store.Service) *Service {
+	return &Service{llmClient: llmClient, store: store}
+}
+
+// pageField is one page's LLM answer for a single schema field.
+type pageField struct {
+	Value          interface{} `json:"value"`
+	Confidence     float64     `json:"confidence"`
+	SourceBlockIDs []int       `json:"source_block_ids"`
+}
+
+type pageAnswer struct {
+	Fields map[string]pageField `json:"fields"`
+}
+
+// Execute walks requestID's pages in order, asking the LLM to populate
+// schema from each page's text, and merges the result by keeping - per
+// field - the highest-confidence non-null answer seen across all pages.
+func (s *Service) Execute(ctx context.Context, requestID string, schema map[string]interface{}, opts Options) (*models.ExtractResult, error) {
+	maxTokens := opts.MaxTokensPerPage
+	if maxTokens <= 0 {
+		maxTokens = defaultMaxTokensPerPage
+	}
+
+	schemaJSON, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal schema: %w", err)
+	}
+	systemPrompt := fmt.Sprintf(systemPromptTemplate, string(schemaJSON))
+
+	byPage, err := s.store.GetRequestBlocks(ctx, requestID)
+	if err != nil {
+		return nil, err
+	}
+
+	pageNumbers := make([]int, 0, len(byPage))
+	for pageNumber := range byPage {
+		pageNumbers = append(pageNumbers, pageNumber)
+	}
+	sort.Ints(pageNumbers)
+
+	result := &models.ExtractResult{
+		RequestID:          requestID,
+		Data:               make(map[string]interface{}),
+		PerFieldConfidence: make(map[string]float64),
+		SourceBlockIDs:     make(map[string][]int),
+	}
+
+	for _, pageNumber := range pageNumbers {
+		pageText := renderPageText(byPage[pageNumber])
+		if strings.TrimSpace(pageText) != "" {
+			raw, err := s.llmClient.AnalyzeTextJSON(ctx, pageText, systemPrompt, 0.1, maxTokens)
+			if err != nil {
+				return nil, fmt.Errorf("extraction failed for page %d: %w", pageNumber, err)
+			}
+
+			var answer pageAnswer
+			if err := json.Unmarshal([]byte(raw), &answer); err != nil {
+				return nil, fmt.Errorf("failed to parse extraction response for page %d: %w", pageNumber, err)
+			}
+
+			for field, pf := range answer.Fields {
+				if pf.Value == nil {
+					continue
+				}
+				if existing, ok := result.PerFieldConfidence[field]; ok && existing >= pf.Confidence {
+					continue
+				}
+				result.Data[field] = pf.Value
+				result.PerFieldConfidence[field] = pf.Confidence
+				result.SourceBlockIDs[field] = pf.SourceBlockIDs
+			}
+		}
+
+		if opts.Progress != nil {
+			opts.Progress(ProgressEvent{PageNumber: pageNumber, PageTotal: len(pageNumbers)})
+		}
+	}
+
+	return result, nil
+}
+
+// renderPageText lays out a page's blocks in reading order (top-to-bottom,
+// left-to-right), each prefixed with its block ID so the LLM can cite the
+// blocks a value came from.
+func renderPageText(blocks []models.BlockInfo) string {
+	sorted := make([]models.BlockInfo, len(blocks))
+	copy(sorted, blocks)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].BBox.Y != sorted[j].BBox.Y {
+			return sorted[i].BBox.Y < sorted[j].BBox.Y
+		}
+		return sorted[i].BBox.X < sorted[j].BBox.X
+	})
+
+	var b strings.Builder
+	for _, block := range sorted {
+		if strings.TrimSpace(block.Text) == "" {
+			continue
+		}
+		fmt.Fprintf(&b, "[%d] %s\n", block.ID, block.Text)
+	}
+	return b.String()
+}
@@ -0,0 +1,209 @@
+package Backend
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"image"
+	_ "image/jpeg"
+	_ "image/png"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/plobin/genkitgo/internal/models"
+)
+
+// awsTextractBackend calls AWS Textract's DetectDocumentText API directly
+// over its JSON protocol, signing requests with SigV4 by hand so this
+// adapter doesn't pull in the full AWS SDK for one API call.
+type awsTextractBackend struct {
+	region          string
+	accessKeyID     string
+	secretAccessKey string
+	client          *http.Client
+}
+
+func init() {
+	region := os.Getenv("AWS_TEXTRACT_REGION")
+	accessKeyID := os.Getenv("AWS_ACCESS_KEY_ID")
+	secretAccessKey := os.Getenv("AWS_SECRET_ACCESS_KEY")
+	if region != "" && accessKeyID != "" && secretAccessKey != "" {
+		Register("aws_textract", NewAWSTextractBackend(region, accessKeyID, secretAccessKey))
+	}
+}
+
+// NewAWSTextractBackend creates a Backend that calls AWS Textract in region
+// using the given credentials.
+func NewAWSTextractBackend(region, accessKeyID, secretAccessKey string) Backend {
+	return &awsTextractBackend{
+		region:          region,
+		accessKeyID:     accessKeyID,
+		secretAccessKey: secretAccessKey,
+		client:          &http.Client{Timeout: time.Minute},
+	}
+}
+
+func (b *awsTextractBackend) Name() string { return "aws_textract" }
+
+type textractRequest struct {
+	Document struct {
+		Bytes string `json:"Bytes"`
+	} `json:"Document"`
+}
+
+type textractResponse struct {
+	Blocks []struct {
+		BlockType  string  `json:"BlockType"`
+		Text       string  `json:"Text"`
+		Confidence float64 `json:"Confidence"`
+		Geometry   struct {
+			BoundingBox struct {
+				Left   float64 `json:"Left"`
+				Top    float64 `json:"Top"`
+				Width  float64 `json:"Width"`
+				Height float64 `json:"Height"`
+			} `json:"BoundingBox"`
+		} `json:"Geometry"`
+	} `json:"Blocks"`
+}
+
+func (b *awsTextractBackend) Recognize(ctx context.Context, imagePath string, opts RecognizeOptions) ([]models.BlockInfo, error) {
+	raw, err := os.ReadFile(imagePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read image: %w", err)
+	}
+
+	imgConfig, _, err := image.DecodeConfig(bytes.NewReader(raw))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read image dimensions: %w", err)
+	}
+
+	var reqBody textractRequest
+	reqBody.Document.Bytes = base64.StdEncoding.EncodeToString(raw)
+	payload, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal Textract request: %w", err)
+	}
+
+	host := fmt.Sprintf("textract.%s.amazonaws.com", b.region)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://"+host+"/", bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Textract request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", "Textract.DetectDocumentText")
+	req.Host = host
+
+	if err := b.sign(req, payload); err != nil {
+		return nil, fmt.Errorf("failed to sign Textract request: %w", err)
+	}
+
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("textract request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var parsed textractResponse
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return nil, fmt.Errorf("failed to decode Textract response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("textract API error: status %d", resp.StatusCode)
+	}
+
+	var blocks []models.BlockInfo
+	id := 0
+	for _, block := range parsed.Blocks {
+		if block.BlockType != "LINE" {
+			continue
+		}
+		bbox := block.Geometry.BoundingBox
+		blocks = append(blocks, models.BlockInfo{
+			ID:         id,
+			Text:       block.Text,
+			Confidence: block.Confidence / 100, // Textract reports 0-100
+			BBox: models.BBox{
+				// Textract reports Geometry.BoundingBox normalized to [0,1]
+				// of the page, unlike every other backend's pixel-space
+				// BBox, so denormalize against the actual decoded image
+				// size - a bare constant here would only agree with pixels
+				// for an image exactly that many pixels square.
+				X:      int(bbox.Left * float64(imgConfig.Width)),
+				Y:      int(bbox.Top * float64(imgConfig.Height)),
+				Width:  int(bbox.Width * float64(imgConfig.Width)),
+				Height: int(bbox.Height * float64(imgConfig.Height)),
+			},
+			BlockType: models.BlockTypeText,
+			Language:  opts.Language,
+		})
+		id++
+	}
+	return blocks, nil
+}
+
+// sign adds AWS Signature Version 4 headers to req, signing payload for
+// the "textract" service in b.region.
+func (b *awsTextractBackend) sign(req *http.Request, payload []byte) error {
+	now := time.Now().UTC()
+	amzDate := now.Format("20060102T150405Z")
+	dateStamp := now.Format("20060102")
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	payloadHash := sha256Hex(payload)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	canonicalHeaders := fmt.Sprintf("content-type:%s\nhost:%s\nx-amz-content-sha256:%s\nx-amz-date:%s\nx-amz-target:%s\n",
+		req.Header.Get("Content-Type"), req.Host, payloadHash, amzDate, req.Header.Get("X-Amz-Target"))
+	signedHeaders := "content-type;host;x-amz-content-sha256;x-amz-date;x-amz-target"
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		"/",
+		"",
+		canonicalHeaders,
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	credentialScope := fmt.Sprintf("%s/%s/textract/aws4_request", dateStamp, b.region)
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		credentialScope,
+		sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+
+	signingKey := signatureKey(b.secretAccessKey, dateStamp, b.region, "textract")
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	authHeader := fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		b.accessKeyID, credentialScope, signedHeaders, signature)
+	req.Header.Set("Authorization", authHeader)
+	return nil
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+func signatureKey(secretKey, dateStamp, region, service string) []byte {
+	kDate := hmacSHA256([]byte("AWS4"+secretKey), dateStamp)
+	kRegion := hmacSHA256(kDate, region)
+	kService := hmacSHA256(kRegion, service)
+	return hmacSHA256(kService, "aws4_request")
+}
@@ -0,0 +1,172 @@
+package Backend
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/plobin/genkitgo/internal/models"
+)
+
+const azureDIAPIVersion = "2023-07-31"
+
+// azureDIBackend calls Azure AI Document Intelligence's prebuilt-read
+// model, which is async: submitting a document returns an Operation-Location
+// to poll until the analysis finishes.
+type azureDIBackend struct {
+	endpoint string
+	apiKey   string
+	client   *http.Client
+}
+
+func init() {
+	endpoint := os.Getenv("AZURE_DI_ENDPOINT")
+	apiKey := os.Getenv("AZURE_DI_API_KEY")
+	if endpoint != "" && apiKey != "" {
+		Register("azure_di", NewAzureDIBackend(endpoint, apiKey))
+	}
+}
+
+// NewAzureDIBackend creates a Backend that calls Azure Document
+// Intelligence at endpoint (e.g. "https://my-resource.cognitiveservices.azure.com").
+func NewAzureDIBackend(endpoint, apiKey string) Backend {
+	return &azureDIBackend{
+		endpoint: endpoint,
+		apiKey:   apiKey,
+		client:   &http.Client{Timeout: 2 * time.Minute},
+	}
+}
+
+func (b *azureDIBackend) Name() string { return "azure_di" }
+
+type azureDIAnalyzeResult struct {
+	Status        string `json:"status"`
+	AnalyzeResult struct {
+		Pages []struct {
+			Words []struct {
+				Content    string    `json:"content"`
+				Confidence float64   `json:"confidence"`
+				Polygon    []float64 `json:"polygon"`
+			} `json:"words"`
+		} `json:"pages"`
+	} `json:"analyzeResult"`
+}
+
+func (b *azureDIBackend) Recognize(ctx context.Context, imagePath string, opts RecognizeOptions) ([]models.BlockInfo, error) {
+	raw, err := os.ReadFile(imagePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read image: %w", err)
+	}
+
+	submitURL := fmt.Sprintf("%s/formrecognizer/documentModels/prebuilt-read:analyze?api-version=%s", b.endpoint, azureDIAPIVersion)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, submitURL, bytes.NewReader(raw))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Azure DI request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("Ocp-Apim-Subscription-Key", b.apiKey)
+
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("azure DI submit failed: %w", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusAccepted {
+		return nil, fmt.Errorf("azure DI submit error: status %d", resp.StatusCode)
+	}
+
+	operationURL := resp.Header.Get("Operation-Location")
+	if operationURL == "" {
+		return nil, fmt.Errorf("azure DI response missing Operation-Location header")
+	}
+
+	result, err := b.poll(ctx, operationURL)
+	if err != nil {
+		return nil, err
+	}
+
+	var blocks []models.BlockInfo
+	id := 0
+	for _, page := range result.AnalyzeResult.Pages {
+		for _, word := range page.Words {
+			blocks = append(blocks, models.BlockInfo{
+				ID:         id,
+				Text:       word.Content,
+				Confidence: word.Confidence,
+				BBox:       boundingBoxFromPolygon(word.Polygon),
+				BlockType:  models.BlockTypeText,
+				Language:   opts.Language,
+			})
+			id++
+		}
+	}
+	return blocks, nil
+}
+
+// poll repeatedly fetches operationURL until Azure reports the analysis as
+// succeeded or failed, backing off a little between attempts.
+func (b *azureDIBackend) poll(ctx context.Context, operationURL string) (*azureDIAnalyzeResult, error) {
+	for {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, operationURL, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create Azure DI poll request: %w", err)
+		}
+		req.Header.Set("Ocp-Apim-Subscription-Key", b.apiKey)
+
+		resp, err := b.client.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("azure DI poll failed: %w", err)
+		}
+
+		var result azureDIAnalyzeResult
+		decodeErr := json.NewDecoder(resp.Body).Decode(&result)
+		resp.Body.Close()
+		if decodeErr != nil {
+			return nil, fmt.Errorf("failed to decode Azure DI poll response: %w", decodeErr)
+		}
+
+		switch result.Status {
+		case "succeeded":
+			return &result, nil
+		case "failed":
+			return nil, fmt.Errorf("azure DI analysis failed")
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+func boundingBoxFromPolygon(polygon []float64) models.BBox {
+	if len(polygon) < 2 {
+		return models.BBox{}
+	}
+
+	minX, minY := polygon[0], polygon[1]
+	maxX, maxY := polygon[0], polygon[1]
+	for i := 2; i+1 < len(polygon); i += 2 {
+		x, y := polygon[i], polygon[i+1]
+		if x < minX {
+			minX = x
+		}
+		if x > maxX {
+			maxX = x
+		}
+		if y < minY {
+			minY = y
+		}
+		if y > maxY {
+			maxY = y
+		}
+	}
+
+	return models.BBox{X: int(minX), Y: int(minY), Width: int(maxX - minX), Height: int(maxY - minY)}
+}
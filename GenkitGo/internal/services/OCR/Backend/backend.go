@@ -0,0 +1,52 @@
+package Backend
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/plobin/genkitgo/internal/models"
+)
+
+// Backend recognizes text blocks in a single image. Every OCR engine
+// ExtractBlocks and ProcessPDF can call out to - the Surya layout model
+// (over HTTP, via SuryaClient), or a cloud vision API - implements this interface, so
+// which engine actually runs is a matter of selection, not code change.
+type Backend interface {
+	// Name identifies the backend in config, the registry, and on each
+	// models.BlockInfo it produces.
+	Name() string
+	Recognize(ctx context.Context, imagePath string, opts RecognizeOptions) ([]models.BlockInfo, error)
+}
+
+// RecognizeOptions carries the subset of the caller's OCR options a
+// backend needs, without this package depending on ExtractBlocks/ProcessPDF.
+type RecognizeOptions struct {
+	Language      string
+	SuryaEndpoint string
+}
+
+var (
+	mu       sync.RWMutex
+	registry = make(map[string]Backend)
+)
+
+// Register makes a backend available under name for later lookup by Get.
+// Adapters call this from their own package's init(), so adding a new
+// cloud provider never requires editing this file.
+func Register(name string, backend Backend) {
+	mu.Lock()
+	defer mu.Unlock()
+	registry[name] = backend
+}
+
+// Get looks up a previously registered backend by name.
+func Get(name string) (Backend, error) {
+	mu.RLock()
+	defer mu.RUnlock()
+	backend, ok := registry[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown OCR backend: %q", name)
+	}
+	return backend, nil
+}
@@ -0,0 +1,190 @@
+package Backend
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/plobin/genkitgo/internal/models"
+)
+
+const googleVisionEndpoint = "https://vision.googleapis.com/v1/images:annotate"
+
+// googleVisionBackend calls the Google Cloud Vision REST API's
+// DOCUMENT_TEXT_DETECTION feature, which returns per-block confidence and
+// bounding boxes suitable for BlockInfo.
+type googleVisionBackend struct {
+	apiKey string
+	client *http.Client
+}
+
+func init() {
+	if apiKey := os.Getenv("GOOGLE_VISION_API_KEY"); apiKey != "" {
+		Register("google_vision", NewGoogleVisionBackend(apiKey))
+	}
+}
+
+// NewGoogleVisionBackend creates a Backend that calls Google Cloud Vision
+// using apiKey for authentication.
+func NewGoogleVisionBackend(apiKey string) Backend {
+	return &googleVisionBackend{
+		apiKey: apiKey,
+		client: &http.Client{Timeout: time.Minute},
+	}
+}
+
+func (b *googleVisionBackend) Name() string { return "google_vision" }
+
+type googleVisionRequest struct {
+	Requests []googleVisionImageRequest `json:"requests"`
+}
+
+type googleVisionImageRequest struct {
+	Image    googleVisionImage     `json:"image"`
+	Features []googleVisionFeature `json:"features"`
+}
+
+type googleVisionImage struct {
+	Content string `json:"content"`
+}
+
+type googleVisionFeature struct {
+	Type string `json:"type"`
+}
+
+type googleVisionResponseBody struct {
+	Responses []struct {
+		FullTextAnnotation struct {
+			Pages []struct {
+				Blocks []struct {
+					Confidence  float64                  `json:"confidence"`
+					BoundingBox googleVisionBoundingPoly `json:"boundingBox"`
+					Paragraphs  []struct {
+						Words []struct {
+							Symbols []struct {
+								Text string `json:"text"`
+							} `json:"symbols"`
+						} `json:"words"`
+					} `json:"paragraphs"`
+				} `json:"blocks"`
+			} `json:"pages"`
+		} `json:"fullTextAnnotation"`
+		Error *struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	} `json:"responses"`
+}
+
+type googleVisionBoundingPoly struct {
+	Vertices []struct {
+		X int `json:"x"`
+		Y int `json:"y"`
+	} `json:"vertices"`
+}
+
+func (b *googleVisionBackend) Recognize(ctx context.Context, imagePath string, opts RecognizeOptions) ([]models.BlockInfo, error) {
+	raw, err := os.ReadFile(imagePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read image: %w", err)
+	}
+
+	reqBody := googleVisionRequest{
+		Requests: []googleVisionImageRequest{
+			{
+				Image:    googleVisionImage{Content: base64.StdEncoding.EncodeToString(raw)},
+				Features: []googleVisionFeature{{Type: "DOCUMENT_TEXT_DETECTION"}},
+			},
+		},
+	}
+
+	payload, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal Google Vision request: %w", err)
+	}
+
+	url := fmt.Sprintf("%s?key=%s", googleVisionEndpoint, b.apiKey)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Google Vision request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("google vision request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var parsed googleVisionResponseBody
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return nil, fmt.Errorf("failed to decode Google Vision response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("google vision API error: status %d", resp.StatusCode)
+	}
+	if len(parsed.Responses) == 0 {
+		return nil, nil
+	}
+	if parsed.Responses[0].Error != nil {
+		return nil, fmt.Errorf("google vision API error: %s", parsed.Responses[0].Error.Message)
+	}
+
+	var blocks []models.BlockInfo
+	for _, page := range parsed.Responses[0].FullTextAnnotation.Pages {
+		for i, block := range page.Blocks {
+			text := ""
+			for _, paragraph := range block.Paragraphs {
+				for _, word := range paragraph.Words {
+					for _, symbol := range word.Symbols {
+						text += symbol.Text
+					}
+					text += " "
+				}
+			}
+
+			blocks = append(blocks, models.BlockInfo{
+				ID:         i,
+				Text:       text,
+				Confidence: block.Confidence,
+				BBox:       boundingBoxFromVertices(block.BoundingBox.Vertices),
+				BlockType:  models.BlockTypeText,
+				Language:   opts.Language,
+			})
+		}
+	}
+	return blocks, nil
+}
+
+func boundingBoxFromVertices(vertices []struct {
+	X int `json:"x"`
+	Y int `json:"y"`
+}) models.BBox {
+	if len(vertices) == 0 {
+		return models.BBox{}
+	}
+
+	minX, minY := vertices[0].X, vertices[0].Y
+	maxX, maxY := vertices[0].X, vertices[0].Y
+	for _, v := range vertices[1:] {
+		if v.X < minX {
+			minX = v.X
+		}
+		if v.X > maxX {
+			maxX = v.X
+		}
+		if v.Y < minY {
+			minY = v.Y
+		}
+		if v.Y > maxY {
+			maxY = v.Y
+		}
+	}
+
+	return models.BBox{X: minX, Y: minY, Width: maxX - minX, Height: maxY - minY}
+}
@@ -0,0 +1,144 @@
+package Backend
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/plobin/genkitgo/internal/models"
+)
+
+// overlapThreshold is how much two blocks' bounding boxes must overlap
+// (intersection over union) before multiBackend treats them as the same
+// region and picks just one.
+const overlapThreshold = 0.5
+
+// multiBackend runs two backends concurrently and merges their results,
+// keeping the higher-confidence block wherever both found the same region
+// and keeping every block either found nowhere the other did.
+type multiBackend struct {
+	first  Backend
+	second Backend
+}
+
+// NewMultiBackend creates a Backend that runs first and second in parallel
+// and reconciles their output. Register it under a name of your choosing,
+// e.g. Register("multi", NewMultiBackend(a, b)).
+func NewMultiBackend(first, second Backend) Backend {
+	return &multiBackend{first: first, second: second}
+}
+
+func (b *multiBackend) Name() string { return "multi(" + b.first.Name() + "+" + b.second.Name() + ")" }
+
+func (b *multiBackend) Recognize(ctx context.Context, imagePath string, opts RecognizeOptions) ([]models.BlockInfo, error) {
+	var (
+		wg                  sync.WaitGroup
+		firstBlocks, second []models.BlockInfo
+		firstErr, secondErr error
+	)
+
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		firstBlocks, firstErr = b.first.Recognize(ctx, imagePath, opts)
+	}()
+	go func() {
+		defer wg.Done()
+		second, secondErr = b.second.Recognize(ctx, imagePath, opts)
+	}()
+	wg.Wait()
+
+	if firstErr != nil && secondErr != nil {
+		return nil, fmt.Errorf("both backends failed: %s: %v; %s: %v", b.first.Name(), firstErr, b.second.Name(), secondErr)
+	}
+	if firstErr != nil {
+		return second, nil
+	}
+	if secondErr != nil {
+		return firstBlocks, nil
+	}
+
+	return mergeByBoundingBox(firstBlocks, second), nil
+}
+
+// mergeByBoundingBox reconciles two block sets detected over the same
+// image: for overlapping boxes, it keeps the higher-confidence block; every
+// non-overlapping block from either set is kept as-is.
+func mergeByBoundingBox(a, b []models.BlockInfo) []models.BlockInfo {
+	usedB := make([]bool, len(b))
+	merged := make([]models.BlockInfo, 0, len(a)+len(b))
+
+	for _, blockA := range a {
+		bestIdx := -1
+		bestOverlap := 0.0
+		for j, blockB := range b {
+			if usedB[j] {
+				continue
+			}
+			overlap := iou(blockA.BBox, blockB.BBox)
+			if overlap > bestOverlap {
+				bestOverlap = overlap
+				bestIdx = j
+			}
+		}
+
+		if bestIdx >= 0 && bestOverlap >= overlapThreshold {
+			usedB[bestIdx] = true
+			if b[bestIdx].Confidence > blockA.Confidence {
+				merged = append(merged, b[bestIdx])
+			} else {
+				merged = append(merged, blockA)
+			}
+			continue
+		}
+
+		merged = append(merged, blockA)
+	}
+
+	for j, blockB := range b {
+		if !usedB[j] {
+			merged = append(merged, blockB)
+		}
+	}
+
+	for i := range merged {
+		merged[i].ID = i
+	}
+	return merged
+}
+
+// iou returns the intersection-over-union of two bounding boxes, 0 if they
+// don't overlap at all.
+func iou(a, b models.BBox) float64 {
+	x1 := max(a.X, b.X)
+	y1 := max(a.Y, b.Y)
+	x2 := min(a.X+a.Width, b.X+b.Width)
+	y2 := min(a.Y+a.Height, b.Y+b.Height)
+
+	if x2 <= x1 || y2 <= y1 {
+		return 0
+	}
+
+	intersection := float64((x2 - x1) * (y2 - y1))
+	areaA := float64(a.Width * a.Height)
+	areaB := float64(b.Width * b.Height)
+	union := areaA + areaB - intersection
+	if union <= 0 {
+		return 0
+	}
+	return intersection / union
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
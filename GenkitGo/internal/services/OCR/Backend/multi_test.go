@@ -0,0 +1,114 @@
+package Backend
+
+import (
+	"testing"
+
+	"github.com/plobin/genkitgo/internal/models"
+)
+
+func TestIoU(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b models.BBox
+		want float64
+	}{
+		{
+			name: "identical boxes",
+			a:    models.BBox{X: 0, Y: 0, Width: 10, Height: 10},
+			b:    models.BBox{X: 0, Y: 0, Width: 10, Height: 10},
+			want: 1,
+		},
+		{
+			name: "no overlap",
+			a:    models.BBox{X: 0, Y: 0, Width: 10, Height: 10},
+			b:    models.BBox{X: 20, Y: 20, Width: 10, Height: 10},
+			want: 0,
+		},
+		{
+			name: "touching edges count as no overlap",
+			a:    models.BBox{X: 0, Y: 0, Width: 10, Height: 10},
+			b:    models.BBox{X: 10, Y: 0, Width: 10, Height: 10},
+			want: 0,
+		},
+		{
+			name: "half overlap",
+			a:    models.BBox{X: 0, Y: 0, Width: 10, Height: 10},
+			b:    models.BBox{X: 5, Y: 0, Width: 10, Height: 10},
+			want: 50.0 / 150.0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := iou(tt.a, tt.b); got != tt.want {
+				t.Fatalf("iou(%+v, %+v) = %v, want %v", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMergeByBoundingBoxKeepsHigherConfidenceOnOverlap(t *testing.T) {
+	a := []models.BlockInfo{
+		{Text: "low confidence", Confidence: 0.5, BBox: models.BBox{X: 0, Y: 0, Width: 10, Height: 10}},
+	}
+	b := []models.BlockInfo{
+		{Text: "high confidence", Confidence: 0.9, BBox: models.BBox{X: 0, Y: 0, Width: 10, Height: 10}},
+	}
+
+	merged := mergeByBoundingBox(a, b)
+
+	if len(merged) != 1 {
+		t.Fatalf("expected the overlapping pair to merge into 1 block, got %d: %+v", len(merged), merged)
+	}
+	if merged[0].Text != "high confidence" {
+		t.Fatalf("expected the higher-confidence block to win, got %+v", merged[0])
+	}
+}
+
+func TestMergeByBoundingBoxKeepsNonOverlappingBlocksFromBoth(t *testing.T) {
+	a := []models.BlockInfo{
+		{Text: "a block", Confidence: 0.5, BBox: models.BBox{X: 0, Y: 0, Width: 10, Height: 10}},
+	}
+	b := []models.BlockInfo{
+		{Text: "b block", Confidence: 0.5, BBox: models.BBox{X: 100, Y: 100, Width: 10, Height: 10}},
+	}
+
+	merged := mergeByBoundingBox(a, b)
+
+	if len(merged) != 2 {
+		t.Fatalf("expected both disjoint blocks to survive, got %d: %+v", len(merged), merged)
+	}
+}
+
+func TestMergeByBoundingBoxBelowThresholdKeepsBothBlocks(t *testing.T) {
+	a := []models.BlockInfo{
+		{Text: "a block", Confidence: 0.5, BBox: models.BBox{X: 0, Y: 0, Width: 10, Height: 10}},
+	}
+	b := []models.BlockInfo{
+		// Overlaps a's box by only 1/10 in each dimension (IoU 1/199), well under the 0.5 IoU merge threshold.
+		{Text: "b block", Confidence: 0.9, BBox: models.BBox{X: 9, Y: 9, Width: 10, Height: 10}},
+	}
+
+	merged := mergeByBoundingBox(a, b)
+
+	if len(merged) != 2 {
+		t.Fatalf("expected both blocks to survive below the overlap threshold, got %d: %+v", len(merged), merged)
+	}
+}
+
+func TestMergeByBoundingBoxReassignsSequentialIDs(t *testing.T) {
+	a := []models.BlockInfo{
+		{ID: 42, BBox: models.BBox{X: 0, Y: 0, Width: 10, Height: 10}},
+	}
+	b := []models.BlockInfo{
+		{ID: 7, BBox: models.BBox{X: 100, Y: 100, Width: 10, Height: 10}},
+	}
+
+	merged := mergeByBoundingBox(a, b)
+
+	for i, block := range merged {
+		if block.ID != i {
+			t.Fatalf("expected merged block %d to be renumbered to ID %d, got %d", i, i, block.ID)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package Backend
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/plobin/genkitgo/internal/models"
+)
+
+// healthCheckTimeout bounds how long autoBackend waits on a primary
+// backend's Health check before deciding to fail over, so a hung health
+// check can't block a request as long as a hung Recognize call would.
+const healthCheckTimeout = 3 * time.Second
+
+// HealthChecker is optionally implemented by a Backend that can report its
+// own availability without running a full Recognize call. autoBackend uses
+// it, when present, to fail over before spending time on a call that's
+// likely to time out anyway.
+type HealthChecker interface {
+	Health(ctx context.Context) error
+}
+
+// init registers the two config.OCREngine modes that compose other
+// backends rather than calling an engine directly: "auto" (Surya first,
+// Tesseract on failure) and "ensemble" (both, merged by multi.go's IoU
+// logic). Both resolve "surya" and "tesseract" through the registry at
+// call time, so they don't depend on init() order across files.
+func init() {
+	Register("auto", newAutoBackend("surya", "tesseract"))
+	Register("ensemble", newEnsembleBackend("surya", "tesseract"))
+}
+
+// autoBackend tries primaryName first, falling back to fallbackName when
+// the primary fails its health check (or has none available) or its
+// Recognize call errors out.
+type autoBackend struct {
+	primaryName, fallbackName string
+}
+
+func newAutoBackend(primaryName, fallbackName string) Backend {
+	return &autoBackend{primaryName: primaryName, fallbackName: fallbackName}
+}
+
+func (b *autoBackend) Name() string { return "auto(" + b.primaryName + "->" + b.fallbackName + ")" }
+
+func (b *autoBackend) Recognize(ctx context.Context, imagePath string, opts RecognizeOptions) ([]models.BlockInfo, error) {
+	primary, err := Get(b.primaryName)
+	if err != nil {
+		return b.recognizeFallback(ctx, imagePath, opts)
+	}
+
+	if hc, ok := primary.(HealthChecker); ok {
+		hctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+		healthErr := hc.Health(hctx)
+		cancel()
+		if healthErr != nil {
+			return b.recognizeFallback(ctx, imagePath, opts)
+		}
+	}
+
+	blocks, err := primary.Recognize(ctx, imagePath, opts)
+	if err != nil {
+		return b.recognizeFallback(ctx, imagePath, opts)
+	}
+	return blocks, nil
+}
+
+func (b *autoBackend) recognizeFallback(ctx context.Context, imagePath string, opts RecognizeOptions) ([]models.BlockInfo, error) {
+	fallback, err := Get(b.fallbackName)
+	if err != nil {
+		return nil, fmt.Errorf("auto backend: primary %q unavailable and fallback %q not registered: %w", b.primaryName, b.fallbackName, err)
+	}
+	return fallback.Recognize(ctx, imagePath, opts)
+}
+
+// ensembleBackend resolves firstName and secondName through the registry
+// at call time and runs them via NewMultiBackend, reusing multi.go's
+// existing IoU-based merge rather than duplicating it.
+type ensembleBackend struct {
+	firstName, secondName string
+}
+
+func newEnsembleBackend(firstName, secondName string) Backend {
+	return &ensembleBackend{firstName: firstName, secondName: secondName}
+}
+
+func (b *ensembleBackend) Name() string {
+	return "ensemble(" + b.firstName + "+" + b.secondName + ")"
+}
+
+func (b *ensembleBackend) Recognize(ctx context.Context, imagePath string, opts RecognizeOptions) ([]models.BlockInfo, error) {
+	first, err := Get(b.firstName)
+	if err != nil {
+		return nil, fmt.Errorf("ensemble backend: %w", err)
+	}
+	second, err := Get(b.secondName)
+	if err != nil {
+		return nil, fmt.Errorf("ensemble backend: %w", err)
+	}
+	return NewMultiBackend(first, second).Recognize(ctx, imagePath, opts)
+}
@@ -0,0 +1,50 @@
+package Backend
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/plobin/genkitgo/internal/models"
+	"github.com/plobin/genkitgo/internal/services/OCR/SuryaClient"
+)
+
+// suryaBackend adapts SuryaClient, the HTTP client for the ML-based layout
+// detection + OCR service, to the Backend interface.
+type suryaBackend struct {
+	defaultEndpoint string
+	client          *SuryaClient.Service
+}
+
+func init() {
+	Register("surya", NewSuryaBackend(""))
+}
+
+// NewSuryaBackend creates a Backend backed by Surya at endpoint (falling
+// back to SuryaClient's own default when empty).
+func NewSuryaBackend(endpoint string) Backend {
+	return &suryaBackend{
+		defaultEndpoint: endpoint,
+		client:          SuryaClient.NewService(endpoint),
+	}
+}
+
+func (b *suryaBackend) Name() string { return "surya" }
+
+// Health delegates to SuryaClient's own health check - used by autoBackend
+// to fail over to Tesseract when the Surya service is unreachable.
+func (b *suryaBackend) Health(ctx context.Context) error {
+	return b.client.CheckHealth(ctx)
+}
+
+func (b *suryaBackend) Recognize(ctx context.Context, imagePath string, opts RecognizeOptions) ([]models.BlockInfo, error) {
+	client := b.client
+	if opts.SuryaEndpoint != "" && opts.SuryaEndpoint != b.defaultEndpoint {
+		client = SuryaClient.NewService(opts.SuryaEndpoint)
+	}
+
+	result, err := client.ExecuteLayout(ctx, imagePath)
+	if err != nil {
+		return nil, fmt.Errorf("surya OCR failed: %w", err)
+	}
+	return result.Blocks, nil
+}
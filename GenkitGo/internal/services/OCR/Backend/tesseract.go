@@ -0,0 +1,103 @@
+package Backend
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/plobin/genkitgo/internal/models"
+)
+
+// tesseractBackend shells out to the tesseract CLI's TSV output mode
+// rather than binding to it via cgo, so this adapter never adds a native
+// Tesseract/Leptonica build requirement - consistent with the other
+// adapters in this package, which all reach their engine over a process
+// or HTTP call rather than a compiled-in client library.
+type tesseractBackend struct {
+	binPath string
+}
+
+func init() {
+	Register("tesseract", NewTesseractBackend(""))
+}
+
+// NewTesseractBackend creates a Backend that invokes the tesseract binary
+// at binPath, falling back to "tesseract" on PATH when empty.
+func NewTesseractBackend(binPath string) Backend {
+	if binPath == "" {
+		binPath = "tesseract"
+	}
+	return &tesseractBackend{binPath: binPath}
+}
+
+func (b *tesseractBackend) Name() string { return "tesseract" }
+
+// Health reports whether the tesseract binary can be found, without
+// running a full recognition pass. autoBackend consults it only when this
+// backend is the primary; as the fallback of the registered "auto" mode,
+// tesseract is called without a health check.
+func (b *tesseractBackend) Health(ctx context.Context) error {
+	if _, err := exec.LookPath(b.binPath); err != nil {
+		return fmt.Errorf("tesseract binary not found: %w", err)
+	}
+	return nil
+}
+
+func (b *tesseractBackend) Recognize(ctx context.Context, imagePath string, opts RecognizeOptions) ([]models.BlockInfo, error) {
+	args := []string{imagePath, "stdout"}
+	if opts.Language != "" {
+		args = append(args, "-l", opts.Language)
+	}
+	args = append(args, "tsv")
+
+	cmd := exec.CommandContext(ctx, b.binPath, args...)
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("tesseract OCR failed: %w", err)
+	}
+	return parseTesseractTSV(out), nil
+}
+
+// parseTesseractTSV reads tesseract's `tsv` config output, one row per
+// detected element at every hierarchy level (page, block, paragraph,
+// line, word), and keeps only the word-level rows (level 5), which are
+// the only ones carrying text and a meaningful confidence.
+func parseTesseractTSV(out []byte) []models.BlockInfo {
+	var blocks []models.BlockInfo
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	header := true
+	for scanner.Scan() {
+		if header {
+			header = false
+			continue
+		}
+		fields := strings.Split(scanner.Text(), "\t")
+		if len(fields) < 12 || fields[0] != "5" {
+			continue
+		}
+
+		text := strings.TrimSpace(fields[11])
+		if text == "" {
+			continue
+		}
+
+		left, _ := strconv.Atoi(fields[6])
+		top, _ := strconv.Atoi(fields[7])
+		width, _ := strconv.Atoi(fields[8])
+		height, _ := strconv.Atoi(fields[9])
+		conf, _ := strconv.ParseFloat(fields[10], 64)
+
+		blocks = append(blocks, models.BlockInfo{
+			ID:         len(blocks),
+			Text:       text,
+			Confidence: conf / 100.0,
+			BBox:       models.BBox{X: left, Y: top, Width: width, Height: height},
+			BlockType:  models.BlockTypeText,
+		})
+	}
+	return blocks
+}
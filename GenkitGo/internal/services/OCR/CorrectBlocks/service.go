@@ -0,0 +1,129 @@
+// Package CorrectBlocks runs every block of a processed request through the
+// LLM to fix obvious OCR errors (misread characters, dropped punctuation),
+// one block at a time so each correction can be diffed and saved back
+// independently without touching bounding boxes.
+package CorrectBlocks
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/plobin/genkitgo/internal/models"
+	"github.com/plobin/genkitgo/internal/services/Block/UpdateBlock"
+	"github.com/plobin/genkitgo/internal/services/LLM/Client"
+	"github.com/plobin/genkitgo/internal/services/OCR/Store"
+)
+
+// defaultMaxTokensPerBlock bounds a single block's correction call when the
+// caller doesn't set Options.MaxTokensPerBlock.
+const defaultMaxTokensPerBlock = 512
+
+const systemPrompt = `You are an OCR post-correction assistant. You will be given the raw text of a single OCR block. Fix obvious OCR errors - misread characters, dropped punctuation, broken spacing - while preserving the original meaning, language, and line breaks as closely as possible. Respond with ONLY the corrected text, no explanation, no quotes.`
+
+// ProgressEvent is pushed to Options.Progress as correction proceeds, one
+// per block, so an SSE handler can stream progress for multi-page documents.
+type ProgressEvent struct {
+	PageNumber int `json:"page_number"`
+	PageTotal  int `json:"page_total"`
+	BlockDone  int `json:"block_done"`
+	BlockTotal int `json:"block_total"`
+}
+
+// Options controls a single Execute call.
+type Options struct {
+	// DryRun, when true, returns the computed diff without writing any
+	// correction back through UpdateBlock.
+	DryRun bool
+
+	// MaxTokensPerBlock caps the LLM response length for each block's
+	// correction call. <= 0 uses defaultMaxTokensPerBlock.
+	MaxTokensPerBlock int
+
+	// Progress, if set, is called after every block finishes correction.
+	Progress func(ProgressEvent)
+}
+
+// Service corrects OCR text via the LLM and, unless running a dry run,
+// persists corrections through the same path the block-edit UI uses.
+type Service struct {
+	llmClient   *Client.LLMClient
+	store       *Store.Service
+	updateBlock *UpdateBlock.Service
+}
+
+func NewService(llmClient *Client.LLMClient, store *Store.Service, updateBlock *UpdateBlock.Service) *Service {
+	return &Service{llmClient: llmClient, store: store, updateBlock: updateBlock}
+}
+
+// Execute corrects every non-empty block of requestID, page by page in page
+// order. Corrections are diffed against the original text; in dry-run mode
+// nothing is written back, otherwise each changed block is saved via
+// UpdateBlock.Execute, the same path the block-edit UI uses, so bounding
+// boxes are left untouched.
+func (s *Service) Execute(ctx context.Context, requestID string, opts Options) (*models.CorrectBlocksResult, error) {
+	maxTokens := opts.MaxTokensPerBlock
+	if maxTokens <= 0 {
+		maxTokens = defaultMaxTokensPerBlock
+	}
+
+	byPage, err := s.store.GetRequestBlocks(ctx, requestID)
+	if err != nil {
+		return nil, err
+	}
+
+	pageNumbers := make([]int, 0, len(byPage))
+	for pageNumber := range byPage {
+		pageNumbers = append(pageNumbers, pageNumber)
+	}
+	sort.Ints(pageNumbers)
+
+	result := &models.CorrectBlocksResult{
+		RequestID:   requestID,
+		DryRun:      opts.DryRun,
+		Corrections: make([]models.BlockCorrection, 0),
+	}
+
+	for _, pageNumber := range pageNumbers {
+		blocks := byPage[pageNumber]
+		for i, block := range blocks {
+			if strings.TrimSpace(block.Text) != "" {
+				corrected, err := s.llmClient.AnalyzeTextWithLimit(ctx, block.Text, systemPrompt, 0.1, maxTokens)
+				if err != nil {
+					return nil, fmt.Errorf("correction failed for block %d: %w", block.ID, err)
+				}
+				corrected = strings.TrimSpace(corrected)
+
+				changed := corrected != block.Text
+				result.Corrections = append(result.Corrections, models.BlockCorrection{
+					BlockID:    block.ID,
+					PageNumber: pageNumber,
+					Original:   block.Text,
+					Corrected:  corrected,
+					Changed:    changed,
+				})
+
+				if changed {
+					result.BlocksFixed++
+					if !opts.DryRun {
+						if _, err := s.updateBlock.Execute(ctx, requestID, block.ID, corrected); err != nil {
+							return nil, fmt.Errorf("failed to save corrected block %d: %w", block.ID, err)
+						}
+					}
+				}
+			}
+
+			if opts.Progress != nil {
+				opts.Progress(ProgressEvent{
+					PageNumber: pageNumber,
+					PageTotal:  len(pageNumbers),
+					BlockDone:  i + 1,
+					BlockTotal: len(blocks),
+				})
+			}
+		}
+	}
+
+	return result, nil
+}
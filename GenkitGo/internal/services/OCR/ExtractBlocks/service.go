@@ -4,33 +4,108 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/plobin/genkitgo/internal/layout/hierarchy"
 	"github.com/plobin/genkitgo/internal/models"
-	"github.com/plobin/genkitgo/internal/services/OCR/SuryaClient"
+	"github.com/plobin/genkitgo/internal/services/Image/Preprocess"
+	"github.com/plobin/genkitgo/internal/services/OCR/Backend"
 )
 
+const defaultBackendName = "surya"
+
 type Service struct {
-	language    string
-	suryaClient *SuryaClient.Service
+	language   string
+	backend    Backend.Backend
+	preprocess *Preprocess.Service
 }
 
+// NewService creates a Service against the default ("surya") OCR backend.
 func NewService(language string) *Service {
+	return NewServiceWithBackend(language, defaultBackendName)
+}
+
+// NewServiceWithBackend creates a Service against a specific registered OCR
+// backend (see internal/services/OCR/Backend), e.g. to wire OCR_BACKEND or
+// a per-request ?backend= override in at construction time.
+func NewServiceWithBackend(language string, backendName string) *Service {
 	if language == "" {
-		language = "kor+eng"
+		language = DefaultExtractBlocksOptions.Language
+	}
+	if backendName == "" {
+		backendName = defaultBackendName
+	}
+
+	backend, err := Backend.Get(backendName)
+	if err != nil {
+		// Fall back to the always-registered default rather than failing
+		// construction over a typo'd or unconfigured backend name.
+		backend, _ = Backend.Get(defaultBackendName)
 	}
+
 	return &Service{
-		language:    language,
-		suryaClient: SuryaClient.NewService("http://localhost:6004"),
+		language:   language,
+		backend:    backend,
+		preprocess: Preprocess.NewService(),
 	}
 }
 
-// Execute performs OCR on an image using Surya (ML-based layout detection + OCR)
-func (s *Service) Execute(ctx context.Context, imagePath string, options models.OCROptions) (*models.OCRResult, error) {
-	// Call Surya OCR service
-	// Use ExecuteLayout for fast layout detection with structure
-	result, err := s.suryaClient.ExecuteLayout(ctx, imagePath)
+// Execute performs OCR on an image via the service's configured backend
+// (or options.Backend, for a one-off override). Options are resolved
+// through the functional-options API; see types_extractblocks_options.go.
+func (s *Service) Execute(ctx context.Context, imagePath string, opts ...ExtractBlocksOption) (*models.OCRResult, error) {
+	options := resolveExtractBlocksOptions(opts...)
+	if options.Context != nil {
+		ctx = options.Context
+	}
+
+	backend := s.backend
+	if options.Backend != "" && options.Backend != s.backend.Name() {
+		selected, err := Backend.Get(options.Backend)
+		if err != nil {
+			return nil, err
+		}
+		backend = selected
+	}
+
+	if options.Preprocessing() {
+		processedPath, cleanup, err := s.preprocess.ProcessFile(ctx, imagePath, Preprocess.Options{
+			Deskew:   options.Deskew,
+			Binarize: options.Binarize,
+			Denoise:  options.Denoise,
+			Upscale:  options.Upscale,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("preprocessing failed: %w", err)
+		}
+		defer cleanup()
+		imagePath = processedPath
+	}
+
+	blocks, err := backend.Recognize(ctx, imagePath, Backend.RecognizeOptions{
+		Language:      options.Language,
+		SuryaEndpoint: options.SuryaEndpoint,
+	})
 	if err != nil {
-		return nil, fmt.Errorf("surya OCR failed: %w", err)
+		return nil, fmt.Errorf("%s OCR failed: %w", backend.Name(), err)
 	}
 
-	return result, nil
+	totalConfidence := 0.0
+	for i := range blocks {
+		blocks[i].Backend = backend.Name()
+		totalConfidence += blocks[i].Confidence
+	}
+
+	avgConfidence := 0.0
+	if len(blocks) > 0 {
+		avgConfidence = totalConfidence / float64(len(blocks))
+	}
+
+	grouped := hierarchy.BuildHierarchy(blocks, hierarchy.Options{})
+
+	return &models.OCRResult{
+		Blocks:        blocks,
+		TotalBlocks:   len(blocks),
+		AverageConf:   avgConfidence,
+		Sections:      grouped.Sections,
+		HierarchyTree: grouped.HierarchyTree,
+	}, nil
 }
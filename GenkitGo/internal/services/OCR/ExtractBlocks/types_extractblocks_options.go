@@ -0,0 +1,161 @@
+package ExtractBlocks
+
+import (
+	"context"
+
+	"github.com/plobin/genkitgo/internal/models"
+)
+
+// ExtractBlocksOptions centralizes every knob Service.Execute accepts. It is
+// built through functional options so callers only set what they care about,
+// with Apply filling in the rest from a single set of defaults instead of
+// duplicating fallback values across ValidateRequest and NewService.
+type ExtractBlocksOptions struct {
+	ConfidenceThreshold float64
+	MergeBlocks         bool
+	MergeThreshold      int
+	Language            string
+	SuryaEndpoint       string
+	Context             context.Context
+
+	// Backend names which registered OCR backend (see internal/services/OCR/Backend)
+	// to run this call against, overriding the service's configured default.
+	Backend string
+
+	// Preprocessing applied to the image before OCR - see
+	// internal/services/Image/Preprocess. Deskew/Denoise/Binarize all
+	// default to off; Upscale <= 1 means no upscaling.
+	Deskew   bool
+	Binarize string
+	Denoise  bool
+	Upscale  float64
+}
+
+// DefaultExtractBlocksOptions mirrors the defaults that used to be
+// duplicated between ProcessImage's ValidateRequest and NewService.
+var DefaultExtractBlocksOptions = ExtractBlocksOptions{
+	ConfidenceThreshold: 0.5,
+	MergeThreshold:      30,
+	Language:            "kor+eng",
+	SuryaEndpoint:       "http://localhost:6004",
+}
+
+// ExtractBlocksOption mutates an ExtractBlocksOptions being built up.
+type ExtractBlocksOption func(*ExtractBlocksOptions)
+
+// WithConfidenceThreshold sets ConfidenceThreshold. Execute does not filter
+// blocks by it; every block the backend returns is kept.
+func WithConfidenceThreshold(v float64) ExtractBlocksOption {
+	return func(o *ExtractBlocksOptions) { o.ConfidenceThreshold = v }
+}
+
+// WithMergeBlocks sets MergeBlocks. Execute does not merge blocks; the
+// backend's blocks are returned as they are.
+func WithMergeBlocks(v bool) ExtractBlocksOption {
+	return func(o *ExtractBlocksOptions) { o.MergeBlocks = v }
+}
+
+// WithMergeThreshold sets MergeThreshold, the pixel distance for
+// MergeBlocks. Execute does not merge blocks, so it has no effect.
+func WithMergeThreshold(v int) ExtractBlocksOption {
+	return func(o *ExtractBlocksOptions) { o.MergeThreshold = v }
+}
+
+// WithLanguage sets the OCR language hint (e.g. "kor+eng").
+func WithLanguage(v string) ExtractBlocksOption {
+	return func(o *ExtractBlocksOptions) { o.Language = v }
+}
+
+// WithSuryaEndpoint overrides the Surya base URL for this call only.
+func WithSuryaEndpoint(v string) ExtractBlocksOption {
+	return func(o *ExtractBlocksOptions) { o.SuryaEndpoint = v }
+}
+
+// WithContext attaches a deadline/cancellation context that replaces the ctx
+// passed positionally to Execute, for preprocessing and the backend call.
+func WithContext(ctx context.Context) ExtractBlocksOption {
+	return func(o *ExtractBlocksOptions) { o.Context = ctx }
+}
+
+// WithBackend selects a registered OCR backend by name (e.g. "surya",
+// "google_vision") for this call only, overriding the service's default.
+func WithBackend(name string) ExtractBlocksOption {
+	return func(o *ExtractBlocksOptions) { o.Backend = name }
+}
+
+// WithDeskew enables Hough-style deskew before OCR.
+func WithDeskew(v bool) ExtractBlocksOption {
+	return func(o *ExtractBlocksOptions) { o.Deskew = v }
+}
+
+// WithBinarize selects an adaptive-threshold method ("otsu" or "sauvola")
+// to apply before OCR; "" skips binarization.
+func WithBinarize(v string) ExtractBlocksOption {
+	return func(o *ExtractBlocksOptions) { o.Binarize = v }
+}
+
+// WithDenoise enables morphological despeckling before OCR.
+func WithDenoise(v bool) ExtractBlocksOption {
+	return func(o *ExtractBlocksOptions) { o.Denoise = v }
+}
+
+// WithUpscale sets the scale factor applied after the rest of the
+// preprocessing pipeline, e.g. 2 for 2x; <=1 skips upscaling.
+func WithUpscale(v float64) ExtractBlocksOption {
+	return func(o *ExtractBlocksOptions) { o.Upscale = v }
+}
+
+// Preprocessing reports whether any preprocessing step was requested, so
+// Service.Execute can skip the decode/encode round trip entirely when
+// none were.
+func (o ExtractBlocksOptions) Preprocessing() bool {
+	return o.Deskew || o.Binarize != "" || o.Denoise || o.Upscale > 1
+}
+
+// WithOCROptions bridges the legacy models.OCROptions bag - still decoded
+// straight off multipart uploads in cmd/server - into the functional
+// options API without callers having to unpack each field by hand.
+func WithOCROptions(legacy models.OCROptions) ExtractBlocksOption {
+	return func(o *ExtractBlocksOptions) {
+		o.ConfidenceThreshold = legacy.ConfidenceThreshold
+		o.MergeBlocks = legacy.MergeBlocks
+		o.MergeThreshold = legacy.MergeThreshold
+		o.Language = legacy.Language
+		o.Deskew = legacy.Deskew
+		o.Binarize = legacy.Binarize
+		o.Denoise = legacy.Denoise
+		o.Upscale = legacy.Upscale
+	}
+}
+
+// Apply fills any zero-valued field of o with the matching field from
+// defaults, centralizing fallback logic that used to be spread across
+// ValidateRequest and NewService.
+func (o ExtractBlocksOptions) Apply(defaults ExtractBlocksOptions) ExtractBlocksOptions {
+	if o.ConfidenceThreshold == 0 {
+		o.ConfidenceThreshold = defaults.ConfidenceThreshold
+	}
+	if o.MergeThreshold == 0 {
+		o.MergeThreshold = defaults.MergeThreshold
+	}
+	if o.Language == "" {
+		o.Language = defaults.Language
+	}
+	if o.SuryaEndpoint == "" {
+		o.SuryaEndpoint = defaults.SuryaEndpoint
+	}
+	if o.Context == nil {
+		o.Context = defaults.Context
+	}
+	return o
+}
+
+// resolveExtractBlocksOptions applies opts over the zero value and fills any
+// gaps from DefaultExtractBlocksOptions.
+func resolveExtractBlocksOptions(opts ...ExtractBlocksOption) ExtractBlocksOptions {
+	var o ExtractBlocksOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o.Apply(DefaultExtractBlocksOptions)
+}
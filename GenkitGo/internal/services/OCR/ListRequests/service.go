@@ -0,0 +1,21 @@
+package ListRequests
+
+import (
+	"context"
+
+	"github.com/plobin/genkitgo/internal/models"
+	"github.com/plobin/genkitgo/internal/services/OCR/Store"
+)
+
+type Service struct {
+	store *Store.Service
+}
+
+func NewService(store *Store.Service) *Service {
+	return &Service{store: store}
+}
+
+// Execute lists every request indexed in the SQLite store, most recent first.
+func (s *Service) Execute(ctx context.Context) ([]models.RequestMetadata, error) {
+	return s.store.ListRequests(ctx)
+}
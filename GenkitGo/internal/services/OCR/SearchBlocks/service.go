@@ -0,0 +1,20 @@
+package SearchBlocks
+
+import (
+	"context"
+
+	"github.com/plobin/genkitgo/internal/services/OCR/Store"
+)
+
+type Service struct {
+	store *Store.Service
+}
+
+func NewService(store *Store.Service) *Service {
+	return &Service{store: store}
+}
+
+// Execute performs a full-text search across every indexed block.
+func (s *Service) Execute(ctx context.Context, query string, filter Store.SearchFilter, limit int) ([]Store.SearchHit, error) {
+	return s.store.SearchBlocks(ctx, query, filter, limit)
+}
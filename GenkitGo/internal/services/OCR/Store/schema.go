@@ -0,0 +1,71 @@
+package Store
+
+// schema creates the requests/pages/blocks tables plus an FTS5 index over
+// blocks.text. It is safe to run on every startup since every statement is
+// idempotent.
+const schema = `
+CREATE TABLE IF NOT EXISTS requests (
+	id            TEXT PRIMARY KEY,
+	original_file TEXT NOT NULL,
+	file_type     TEXT NOT NULL,
+	file_size     INTEGER NOT NULL,
+	total_pages   INTEGER NOT NULL,
+	status        TEXT NOT NULL,
+	error_message TEXT NOT NULL DEFAULT '',
+	created_at    TEXT NOT NULL,
+	updated_at    TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS pages (
+	request_id   TEXT NOT NULL,
+	page_number  INTEGER NOT NULL,
+	total_blocks INTEGER NOT NULL DEFAULT 0,
+	average_conf REAL NOT NULL DEFAULT 0,
+	PRIMARY KEY (request_id, page_number)
+);
+
+CREATE TABLE IF NOT EXISTS blocks (
+	id           INTEGER PRIMARY KEY,
+	request_id   TEXT NOT NULL,
+	page_number  INTEGER NOT NULL,
+	bbox         TEXT NOT NULL,
+	bbox_points  TEXT NOT NULL DEFAULT '',
+	confidence   REAL NOT NULL,
+	block_type   TEXT NOT NULL,
+	language     TEXT NOT NULL DEFAULT '',
+	layout_label TEXT NOT NULL DEFAULT '',
+	text         TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_blocks_request_page ON blocks (request_id, page_number);
+
+-- block_id_seq is the single-row counter AllocateBlockIDs draws from, so
+-- blocks.id stays globally unique across every request rather than being
+-- numbered from zero per document (which previously let two requests'
+-- blocks collide on id and corrupt each other's rows).
+CREATE TABLE IF NOT EXISTS block_id_seq (
+	id      INTEGER PRIMARY KEY CHECK (id = 1),
+	next_id INTEGER NOT NULL DEFAULT 0
+);
+
+INSERT OR IGNORE INTO block_id_seq (id, next_id) VALUES (1, 0);
+
+CREATE VIRTUAL TABLE IF NOT EXISTS blocks_fts USING fts5 (
+	text,
+	content = 'blocks',
+	content_rowid = 'id'
+);
+
+CREATE TRIGGER IF NOT EXISTS blocks_ai AFTER INSERT ON blocks BEGIN
+	INSERT INTO blocks_fts (rowid, text) VALUES (new.id, new.text);
+END;
+
+CREATE TRIGGER IF NOT EXISTS blocks_ad AFTER DELETE ON blocks BEGIN
+	INSERT INTO blocks_fts (blocks_fts, rowid, text) VALUES ('delete', old.id, old.text);
+END;
+
+CREATE TRIGGER IF NOT EXISTS blocks_au AFTER UPDATE ON blocks BEGIN
+	INSERT INTO blocks_fts (blocks_fts, rowid, text) VALUES ('delete', old.id, old.text);
+	INSERT INTO blocks_fts (rowid, text) VALUES (new.id, new.text);
+END;
+`
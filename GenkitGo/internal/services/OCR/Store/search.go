@@ -0,0 +1,80 @@
+package Store
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// SearchFilter narrows a full-text search to a subset of indexed blocks.
+// Zero values are treated as "no filter".
+type SearchFilter struct {
+	RequestID     string
+	BlockType     string
+	MinConfidence float64
+}
+
+// SearchHit is a single full-text match, including a highlighted snippet of
+// the surrounding text.
+type SearchHit struct {
+	BlockID    int     `json:"block_id"`
+	RequestID  string  `json:"request_id"`
+	PageNumber int     `json:"page_number"`
+	BlockType  string  `json:"block_type"`
+	Confidence float64 `json:"confidence"`
+	Snippet    string  `json:"snippet"`
+}
+
+// SearchBlocks runs an FTS5 match against every indexed block's text,
+// optionally narrowed by SearchFilter, and returns up to limit hits ordered
+// by relevance.
+func (s *Service) SearchBlocks(ctx context.Context, query string, filter SearchFilter, limit int) ([]SearchHit, error) {
+	if strings.TrimSpace(query) == "" {
+		return nil, fmt.Errorf("search query must not be empty")
+	}
+	if limit <= 0 {
+		limit = 20
+	}
+
+	sqlQuery := strings.Builder{}
+	sqlQuery.WriteString(`
+		SELECT b.id, b.request_id, b.page_number, b.block_type, b.confidence,
+		       snippet(blocks_fts, 0, '[', ']', '...', 10)
+		FROM blocks_fts
+		JOIN blocks b ON b.id = blocks_fts.rowid
+		WHERE blocks_fts MATCH ?
+	`)
+	args := []interface{}{query}
+
+	if filter.RequestID != "" {
+		sqlQuery.WriteString(" AND b.request_id = ?")
+		args = append(args, filter.RequestID)
+	}
+	if filter.BlockType != "" {
+		sqlQuery.WriteString(" AND b.block_type = ?")
+		args = append(args, filter.BlockType)
+	}
+	if filter.MinConfidence > 0 {
+		sqlQuery.WriteString(" AND b.confidence >= ?")
+		args = append(args, filter.MinConfidence)
+	}
+
+	sqlQuery.WriteString(" ORDER BY rank LIMIT ?")
+	args = append(args, limit)
+
+	rows, err := s.db.QueryContext(ctx, sqlQuery.String(), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search blocks: %w", err)
+	}
+	defer rows.Close()
+
+	hits := make([]SearchHit, 0)
+	for rows.Next() {
+		var hit SearchHit
+		if err := rows.Scan(&hit.BlockID, &hit.RequestID, &hit.PageNumber, &hit.BlockType, &hit.Confidence, &hit.Snippet); err != nil {
+			return nil, fmt.Errorf("failed to scan search hit: %w", err)
+		}
+		hits = append(hits, hit)
+	}
+	return hits, rows.Err()
+}
@@ -0,0 +1,326 @@
+package Store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	_ "modernc.org/sqlite"
+
+	"github.com/plobin/genkitgo/internal/models"
+)
+
+// Service owns a SQLite-backed index of OCR requests, pages and blocks. It
+// replaces the old page_N.json files so a block can be looked up by ID in a
+// single query and so block text becomes searchable across requests via the
+// blocks_fts virtual table.
+type Service struct {
+	db *sql.DB
+}
+
+// NewService opens (and migrates) the SQLite database at dbPath.
+func NewService(dbPath string) (*Service, error) {
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open sqlite database: %w", err)
+	}
+
+	// modernc.org/sqlite does not support concurrent writers on one handle.
+	db.SetMaxOpenConns(1)
+
+	if _, err := db.Exec(schema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to migrate sqlite database: %w", err)
+	}
+
+	return &Service{db: db}, nil
+}
+
+// Close closes the underlying database handle.
+func (s *Service) Close() error {
+	return s.db.Close()
+}
+
+// SaveRequestMetadata upserts a request's bookkeeping row.
+func (s *Service) SaveRequestMetadata(ctx context.Context, metadata *models.RequestMetadata) error {
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO requests (id, original_file, file_type, file_size, total_pages, status, error_message, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (id) DO UPDATE SET
+			total_pages   = excluded.total_pages,
+			status        = excluded.status,
+			error_message = excluded.error_message,
+			updated_at    = excluded.updated_at
+	`,
+		metadata.RequestID, metadata.OriginalFile, string(metadata.FileType), metadata.FileSize,
+		metadata.TotalPages, string(metadata.Status), metadata.ErrorMessage,
+		metadata.CreatedAt.Format(time.RFC3339), metadata.UpdatedAt.Format(time.RFC3339),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save request metadata: %w", err)
+	}
+	return nil
+}
+
+// GetRequestMetadata retrieves a single request's bookkeeping row.
+func (s *Service) GetRequestMetadata(ctx context.Context, requestID string) (*models.RequestMetadata, error) {
+	row := s.db.QueryRowContext(ctx, `
+		SELECT id, original_file, file_type, file_size, total_pages, status, error_message, created_at, updated_at
+		FROM requests WHERE id = ?
+	`, requestID)
+
+	metadata, err := scanRequestMetadata(row.Scan)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("request not found: %s", requestID)
+		}
+		return nil, fmt.Errorf("failed to read request metadata: %w", err)
+	}
+	return metadata, nil
+}
+
+// ListRequests returns every indexed request, most recently created first.
+func (s *Service) ListRequests(ctx context.Context) ([]models.RequestMetadata, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, original_file, file_type, file_size, total_pages, status, error_message, created_at, updated_at
+		FROM requests ORDER BY created_at DESC
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list requests: %w", err)
+	}
+	defer rows.Close()
+
+	requests := make([]models.RequestMetadata, 0)
+	for rows.Next() {
+		metadata, err := scanRequestMetadata(rows.Scan)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan request metadata: %w", err)
+		}
+		requests = append(requests, *metadata)
+	}
+	return requests, rows.Err()
+}
+
+func scanRequestMetadata(scan func(dest ...interface{}) error) (*models.RequestMetadata, error) {
+	var metadata models.RequestMetadata
+	var fileType, status, createdAt, updatedAt string
+
+	if err := scan(
+		&metadata.RequestID, &metadata.OriginalFile, &fileType, &metadata.FileSize,
+		&metadata.TotalPages, &status, &metadata.ErrorMessage, &createdAt, &updatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	metadata.FileType = models.RequestType(fileType)
+	metadata.Status = models.RequestStatus(status)
+	metadata.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
+	metadata.UpdatedAt, _ = time.Parse(time.RFC3339, updatedAt)
+	return &metadata, nil
+}
+
+// AllocateBlockIDs reserves count consecutive globally-unique block IDs,
+// returning the first one. Block IDs are shared across every request in the
+// blocks table (GetBlockLocation/crop lookups resolve one by ID alone, with
+// no request_id), so callers assigning block.ID before SavePage must draw
+// from this sequence instead of numbering blocks from zero per document.
+func (s *Service) AllocateBlockIDs(ctx context.Context, count int) (int, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var next int
+	if err := tx.QueryRowContext(ctx, `SELECT next_id FROM block_id_seq WHERE id = 1`).Scan(&next); err != nil {
+		return 0, fmt.Errorf("failed to read block id sequence: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE block_id_seq SET next_id = ? WHERE id = 1`, next+count); err != nil {
+		return 0, fmt.Errorf("failed to advance block id sequence: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit block id allocation: %w", err)
+	}
+	return next, nil
+}
+
+// SavePage persists one page's blocks transactionally, replacing whatever
+// was previously stored for that (requestID, pageNumber) pair.
+func (s *Service) SavePage(ctx context.Context, requestID string, pageNumber int, result *models.OCRResult) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM blocks WHERE request_id = ? AND page_number = ?`, requestID, pageNumber); err != nil {
+		return fmt.Errorf("failed to clear existing blocks: %w", err)
+	}
+
+	for _, block := range result.Blocks {
+		bbox, err := json.Marshal(block.BBox)
+		if err != nil {
+			return fmt.Errorf("failed to marshal bbox: %w", err)
+		}
+		bboxPoints, err := json.Marshal(block.BBoxPoints)
+		if err != nil {
+			return fmt.Errorf("failed to marshal bbox points: %w", err)
+		}
+
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO blocks (id, request_id, page_number, bbox, bbox_points, confidence, block_type, language, layout_label, text)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			ON CONFLICT (id) DO UPDATE SET
+				bbox         = excluded.bbox,
+				bbox_points  = excluded.bbox_points,
+				confidence   = excluded.confidence,
+				block_type   = excluded.block_type,
+				language     = excluded.language,
+				layout_label = excluded.layout_label,
+				text         = excluded.text
+		`,
+			block.ID, requestID, pageNumber, string(bbox), string(bboxPoints),
+			block.Confidence, string(block.BlockType), block.Language, block.LayoutLabel, block.Text,
+		); err != nil {
+			return fmt.Errorf("failed to insert block %d: %w", block.ID, err)
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO pages (request_id, page_number, total_blocks, average_conf)
+		VALUES (?, ?, ?, ?)
+		ON CONFLICT (request_id, page_number) DO UPDATE SET
+			total_blocks = excluded.total_blocks,
+			average_conf = excluded.average_conf
+	`, requestID, pageNumber, result.TotalBlocks, result.AverageConf); err != nil {
+		return fmt.Errorf("failed to upsert page row: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// GetPage reconstructs the OCR result for a single page from the blocks
+// table.
+func (s *Service) GetPage(ctx context.Context, requestID string, pageNumber int) (*models.OCRResult, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, bbox, bbox_points, confidence, block_type, language, layout_label, text
+		FROM blocks WHERE request_id = ? AND page_number = ? ORDER BY id
+	`, requestID, pageNumber)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read page: %w", err)
+	}
+	defer rows.Close()
+
+	result := &models.OCRResult{RequestID: requestID, Blocks: make([]models.BlockInfo, 0)}
+	confSum := 0.0
+
+	for rows.Next() {
+		block, err := scanBlock(rows.Scan)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan block: %w", err)
+		}
+		confSum += block.Confidence
+		result.Blocks = append(result.Blocks, *block)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(result.Blocks) == 0 {
+		return nil, fmt.Errorf("page %d not found in request %s", pageNumber, requestID)
+	}
+
+	result.TotalBlocks = len(result.Blocks)
+	result.AverageConf = confSum / float64(len(result.Blocks))
+	return result, nil
+}
+
+// GetRequestBlocks returns every block indexed for requestID, grouped by
+// page number, for callers (such as template application) that need the
+// whole document rather than one page at a time.
+func (s *Service) GetRequestBlocks(ctx context.Context, requestID string) (map[int][]models.BlockInfo, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT page_number, id, bbox, bbox_points, confidence, block_type, language, layout_label, text
+		FROM blocks WHERE request_id = ? ORDER BY page_number, id
+	`, requestID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read request blocks: %w", err)
+	}
+	defer rows.Close()
+
+	byPage := make(map[int][]models.BlockInfo)
+	for rows.Next() {
+		var pageNumber int
+		block, err := scanBlock(func(dest ...interface{}) error {
+			return rows.Scan(append([]interface{}{&pageNumber}, dest...)...)
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan block: %w", err)
+		}
+		byPage[pageNumber] = append(byPage[pageNumber], *block)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(byPage) == 0 {
+		return nil, fmt.Errorf("no blocks found for request %s", requestID)
+	}
+	return byPage, nil
+}
+
+func scanBlock(scan func(dest ...interface{}) error) (*models.BlockInfo, error) {
+	var block models.BlockInfo
+	var bbox, bboxPoints, blockType string
+
+	if err := scan(&block.ID, &bbox, &bboxPoints, &block.Confidence, &blockType, &block.Language, &block.LayoutLabel, &block.Text); err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal([]byte(bbox), &block.BBox); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal bbox: %w", err)
+	}
+	if bboxPoints != "" {
+		if err := json.Unmarshal([]byte(bboxPoints), &block.BBoxPoints); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal bbox points: %w", err)
+		}
+	}
+	block.BlockType = models.BlockType(blockType)
+
+	return &block, nil
+}
+
+// GetBlockLocation resolves which request and page a globally-unique block
+// ID belongs to, so callers can find artifacts (crops, source images) that
+// are stored on disk keyed by (requestID, pageNumber) rather than by block.
+func (s *Service) GetBlockLocation(ctx context.Context, blockID int) (requestID string, pageNumber int, err error) {
+	row := s.db.QueryRowContext(ctx, `SELECT request_id, page_number FROM blocks WHERE id = ?`, blockID)
+	if err := row.Scan(&requestID, &pageNumber); err != nil {
+		if err == sql.ErrNoRows {
+			return "", 0, fmt.Errorf("block %d not found", blockID)
+		}
+		return "", 0, fmt.Errorf("failed to locate block: %w", err)
+	}
+	return requestID, pageNumber, nil
+}
+
+// DeleteBlock removes a single block by its globally-unique ID, scoped to
+// requestID so a stale or mistyped ID can't delete another request's data.
+func (s *Service) DeleteBlock(ctx context.Context, requestID string, blockID int) error {
+	result, err := s.db.ExecContext(ctx, `DELETE FROM blocks WHERE id = ? AND request_id = ?`, blockID, requestID)
+	if err != nil {
+		return fmt.Errorf("failed to delete block: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to confirm delete: %w", err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("block %d not found in request %s", blockID, requestID)
+	}
+	return nil
+}
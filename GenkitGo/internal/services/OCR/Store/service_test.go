@@ -0,0 +1,101 @@
+package Store
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/plobin/genkitgo/internal/models"
+)
+
+func newTestService(t *testing.T) *Service {
+	t.Helper()
+	s, err := NewService(filepath.Join(t.TempDir(), "ocr_index.db"))
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	t.Cleanup(func() { s.Close() })
+	return s
+}
+
+func TestAllocateBlockIDsAdvancesPastPreviousAllocations(t *testing.T) {
+	s := newTestService(t)
+	ctx := context.Background()
+
+	first, err := s.AllocateBlockIDs(ctx, 5)
+	if err != nil {
+		t.Fatalf("AllocateBlockIDs: %v", err)
+	}
+	if first != 0 {
+		t.Fatalf("expected first allocation to start at 0, got %d", first)
+	}
+
+	second, err := s.AllocateBlockIDs(ctx, 3)
+	if err != nil {
+		t.Fatalf("AllocateBlockIDs: %v", err)
+	}
+	if second != first+5 {
+		t.Fatalf("expected second allocation to start at %d, got %d", first+5, second)
+	}
+}
+
+func TestSaveAndGetPageRoundTrips(t *testing.T) {
+	s := newTestService(t)
+	ctx := context.Background()
+
+	result := &models.OCRResult{
+		Blocks: []models.BlockInfo{
+			{ID: 1, Text: "hello", Confidence: 0.9, BBox: models.BBox{X: 1, Y: 2, Width: 3, Height: 4}},
+			{ID: 2, Text: "world", Confidence: 0.8, BBox: models.BBox{X: 5, Y: 6, Width: 7, Height: 8}},
+		},
+	}
+
+	if err := s.SavePage(ctx, "req-1", 0, result); err != nil {
+		t.Fatalf("SavePage: %v", err)
+	}
+
+	page, err := s.GetPage(ctx, "req-1", 0)
+	if err != nil {
+		t.Fatalf("GetPage: %v", err)
+	}
+	if len(page.Blocks) != 2 {
+		t.Fatalf("expected 2 blocks, got %d", len(page.Blocks))
+	}
+	if page.Blocks[0].Text != "hello" || page.Blocks[1].Text != "world" {
+		t.Fatalf("unexpected block contents: %+v", page.Blocks)
+	}
+}
+
+func TestGetPageNotFound(t *testing.T) {
+	s := newTestService(t)
+	if _, err := s.GetPage(context.Background(), "missing", 0); err == nil {
+		t.Fatal("expected an error for a page that was never saved")
+	}
+}
+
+func TestDeleteBlockRemovesItFromRequestBlocks(t *testing.T) {
+	s := newTestService(t)
+	ctx := context.Background()
+
+	result := &models.OCRResult{
+		Blocks: []models.BlockInfo{
+			{ID: 10, Text: "keep"},
+			{ID: 11, Text: "drop"},
+		},
+	}
+	if err := s.SavePage(ctx, "req-2", 0, result); err != nil {
+		t.Fatalf("SavePage: %v", err)
+	}
+
+	if err := s.DeleteBlock(ctx, "req-2", 11); err != nil {
+		t.Fatalf("DeleteBlock: %v", err)
+	}
+
+	byPage, err := s.GetRequestBlocks(ctx, "req-2")
+	if err != nil {
+		t.Fatalf("GetRequestBlocks: %v", err)
+	}
+	if len(byPage[0]) != 1 || byPage[0][0].ID != 10 {
+		t.Fatalf("expected only block 10 to remain, got %+v", byPage[0])
+	}
+}
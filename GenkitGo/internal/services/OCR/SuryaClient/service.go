@@ -6,12 +6,14 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"log/slog"
 	"mime/multipart"
 	"net/http"
 	"os"
 	"path/filepath"
 	"time"
 
+	"github.com/plobin/genkitgo/internal/logger"
 	"github.com/plobin/genkitgo/internal/models"
 )
 
@@ -24,10 +26,10 @@ type SuryaResponse struct {
 	Success bool   `json:"success"`
 	Message string `json:"message"`
 	Data    struct {
-		TotalBlocks      int                  `json:"total_blocks"`
-		AverageConfidence float64             `json:"average_confidence,omitempty"`
-		Blocks           []models.BlockInfo   `json:"blocks"`
-		ProcessingTime   float64              `json:"processing_time"`
+		TotalBlocks       int                `json:"total_blocks"`
+		AverageConfidence float64            `json:"average_confidence,omitempty"`
+		Blocks            []models.BlockInfo `json:"blocks"`
+		ProcessingTime    float64            `json:"processing_time"`
 	} `json:"data"`
 }
 
@@ -162,8 +164,13 @@ func (s *Service) callSuryaAPI(ctx context.Context, imagePath string, endpoint s
 
 	// Log processing time
 	totalTime := time.Since(startTime)
-	fmt.Printf("Surya %s: %d blocks, %.2fs (API: %.2fs)\n",
-		endpoint, result.TotalBlocks, totalTime.Seconds(), suryaResp.Data.ProcessingTime)
+	slog.InfoContext(ctx, "surya OCR call",
+		"request_id", logger.RequestIDFromContext(ctx),
+		"endpoint", endpoint,
+		"blocks", result.TotalBlocks,
+		"duration_seconds", totalTime.Seconds(),
+		"api_duration_seconds", suryaResp.Data.ProcessingTime,
+	)
 
 	return result, nil
 }
@@ -3,18 +3,42 @@ package ProcessPDF
 import (
 	"context"
 	"fmt"
+	"image"
 	"image/png"
+	"log"
 	"os"
 	"path/filepath"
 
 	"github.com/gen2brain/go-fitz"
+	"github.com/plobin/genkitgo/internal/layout/hierarchy"
 	"github.com/plobin/genkitgo/internal/models"
+	"github.com/plobin/genkitgo/internal/services/Image/Preprocess"
 	"github.com/plobin/genkitgo/internal/services/OCR/ExtractBlocks"
+	"github.com/plobin/genkitgo/internal/services/OCR/Store"
+	"github.com/plobin/genkitgo/internal/services/Visualization/CropBlocks"
 )
 
+const (
+	cropWorkerCount = 4 // bounded pool so cropping never blocks PDF ingestion
+	cropQueueDepth  = 32
+	cropThumbMaxDim = 512
+)
+
+type cropJob struct {
+	img        image.Image
+	requestID  string
+	pageNumber int
+	blocks     []models.BlockInfo
+}
+
 type Service struct {
-	ocrService *ExtractBlocks.Service
-	dpi        float64
+	ocrService  *ExtractBlocks.Service
+	store       *Store.Service
+	cropService *CropBlocks.Service
+	preprocess  *Preprocess.Service
+	baseDir     string
+	defaults    ProcessPDFOptions
+	cropJobs    chan cropJob
 }
 
 type PDFResult struct {
@@ -25,18 +49,72 @@ type PDFResult struct {
 	Pages       []models.OCRResult `json:"pages"`
 }
 
-func NewService(language string, dpi float64) *Service {
-	if dpi == 0 {
-		dpi = 150.0  // Default DPI
+func NewService(language string, dpi float64, store *Store.Service, cropService *CropBlocks.Service) *Service {
+	return NewServiceWithBackend(language, dpi, "", store, cropService)
+}
+
+// NewServiceWithBackend is NewService with an explicit OCR backend name
+// (see internal/services/OCR/Backend), e.g. to wire OCR_BACKEND or a
+// per-request ?backend= override in at construction time. Preprocessed
+// pages are cached under cropService's own base directory, alongside the
+// thumbnails it already writes there.
+func NewServiceWithBackend(language string, dpi float64, backendName string, store *Store.Service, cropService *CropBlocks.Service) *Service {
+	defaults := DefaultProcessPDFOptions
+	if language != "" {
+		defaults.Language = language
 	}
-	return &Service{
-		ocrService: ExtractBlocks.NewService(language),
-		dpi:        dpi,
+	if dpi != 0 {
+		defaults.DPI = dpi
 	}
+	defaults.Backend = backendName
+
+	s := &Service{
+		ocrService:  ExtractBlocks.NewServiceWithBackend(defaults.Language, backendName),
+		store:       store,
+		cropService: cropService,
+		preprocess:  Preprocess.NewService(),
+		baseDir:     cropService.BaseDir(),
+		defaults:    defaults,
+		cropJobs:    make(chan cropJob, cropQueueDepth),
+	}
+
+	for i := 0; i < cropWorkerCount; i++ {
+		go s.runCropWorker()
+	}
+
+	return s
 }
 
-// Execute processes a PDF file: converts to images and performs OCR on each page
-func (s *Service) Execute(ctx context.Context, pdfPath string, options models.OCROptions) (*PDFResult, error) {
+// runCropWorker drains cropJobs in the background so CropBlocks' per-region
+// PNG encoding never blocks a caller waiting on Execute.
+func (s *Service) runCropWorker() {
+	for job := range s.cropJobs {
+		if err := s.cropService.Execute(context.Background(), job.img, job.requestID, job.pageNumber, job.blocks, cropThumbMaxDim); err != nil {
+			log.Printf("CropBlocks failed for request %s page %d: %v", job.requestID, job.pageNumber, err)
+		}
+	}
+}
+
+// enqueueCrop hands a page off to the worker pool, dropping the job rather
+// than blocking ingestion if every worker is still busy.
+func (s *Service) enqueueCrop(job cropJob) {
+	select {
+	case s.cropJobs <- job:
+	default:
+		log.Printf("Crop queue full, dropping thumbnails for request %s page %d", job.requestID, job.pageNumber)
+	}
+}
+
+// Execute processes a PDF file: converts to images, performs OCR on each
+// page, and writes each page's blocks into the SQLite store transactionally
+// as it goes, keyed by requestID. Options are resolved through the
+// functional-options API; see types_processpdf_options.go.
+func (s *Service) Execute(ctx context.Context, pdfPath string, requestID string, opts ...ProcessPDFOption) (*PDFResult, error) {
+	options := resolveProcessPDFOptions(s.defaults, opts...)
+	if options.Context != nil {
+		ctx = options.Context
+	}
+
 	// Open PDF document
 	doc, err := fitz.New(pdfPath)
 	if err != nil {
@@ -62,8 +140,8 @@ func (s *Service) Execute(ctx context.Context, pdfPath string, options models.OC
 	totalConfidence := 0.0
 
 	for pageNum := 0; pageNum < pageCount; pageNum++ {
-		// Render page to image
-		img, err := doc.Image(pageNum)
+		// Render page to image at the resolved DPI
+		img, err := doc.ImageDPI(pageNum, options.DPI)
 		if err != nil {
 			return nil, fmt.Errorf("failed to render page %d: %w", pageNum+1, err)
 		}
@@ -81,21 +159,65 @@ func (s *Service) Execute(ctx context.Context, pdfPath string, options models.OC
 		}
 		file.Close()
 
+		ocrPath := imagePath
+		if options.Preprocessing() {
+			preOpts := Preprocess.Options{
+				Deskew:   options.Deskew,
+				Binarize: options.Binarize,
+				Denoise:  options.Denoise,
+				Upscale:  options.Upscale,
+			}
+			cachePath := filepath.Join(s.baseDir, requestID, "preprocessed", fmt.Sprintf("page_%d_%s.png", pageNum+1, Preprocess.CacheKey(preOpts)))
+			cached, err := s.preprocess.ProcessFileCached(ctx, imagePath, cachePath, preOpts)
+			if err != nil {
+				return nil, fmt.Errorf("failed to preprocess page %d: %w", pageNum+1, err)
+			}
+			ocrPath = cached
+		}
+
 		// Perform OCR on the image
-		result, err := s.ocrService.Execute(ctx, imagePath, options)
+		result, err := s.ocrService.Execute(ctx, ocrPath,
+			ExtractBlocks.WithConfidenceThreshold(options.ConfidenceThreshold),
+			ExtractBlocks.WithMergeBlocks(options.MergeBlocks),
+			ExtractBlocks.WithMergeThreshold(options.MergeThreshold),
+			ExtractBlocks.WithLanguage(options.Language),
+			ExtractBlocks.WithSuryaEndpoint(options.SuryaEndpoint),
+			ExtractBlocks.WithBackend(options.Backend),
+		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to OCR page %d: %w", pageNum+1, err)
 		}
 
-		// Update block IDs to be globally unique
+		// Assign globally-unique block IDs (shared across every request, not
+		// just this document - see Store.AllocateBlockIDs).
+		idBase, err := s.store.AllocateBlockIDs(ctx, len(result.Blocks))
+		if err != nil {
+			return nil, fmt.Errorf("failed to allocate block ids for page %d: %w", pageNum+1, err)
+		}
 		for i := range result.Blocks {
-			result.Blocks[i].ID = totalBlocks + i
+			result.Blocks[i].ID = idBase + i
 		}
 
+		// Recompute the hierarchy against the now-global IDs - the one
+		// ExtractBlocks.Execute built referenced this page's local IDs.
+		grouped := hierarchy.BuildHierarchy(result.Blocks, hierarchy.Options{})
+		result.Sections = grouped.Sections
+		result.HierarchyTree = grouped.HierarchyTree
+
 		totalBlocks += result.TotalBlocks
 		totalConfidence += result.AverageConf
 
+		if err := s.store.SavePage(ctx, requestID, pageNum+1, result); err != nil {
+			return nil, fmt.Errorf("failed to save page %d to store: %w", pageNum+1, err)
+		}
+
+		s.enqueueCrop(cropJob{img: img, requestID: requestID, pageNumber: pageNum + 1, blocks: result.Blocks})
+
 		pages = append(pages, *result)
+
+		if options.Progress != nil {
+			options.Progress(pageNum+1, pageCount)
+		}
 	}
 
 	// Calculate overall average confidence
@@ -105,7 +227,7 @@ func (s *Service) Execute(ctx context.Context, pdfPath string, options models.OC
 	}
 
 	return &PDFResult{
-		RequestID:   "",
+		RequestID:   requestID,
 		TotalPages:  pageCount,
 		TotalBlocks: totalBlocks,
 		AverageConf: avgConfidence,
@@ -0,0 +1,185 @@
+package ProcessPDF
+
+import (
+	"context"
+
+	"github.com/plobin/genkitgo/internal/models"
+)
+
+// ProcessPDFOptions centralizes every knob Service.Execute accepts. Built
+// through functional options, mirroring ExtractBlocksOptions, so the same
+// defaults aren't duplicated between the HTTP handler and the service.
+type ProcessPDFOptions struct {
+	ConfidenceThreshold float64
+	MergeBlocks         bool
+	MergeThreshold      int
+	Language            string
+	SuryaEndpoint       string
+	DPI                 float64
+	Context             context.Context
+
+	// Backend names which registered OCR backend (see
+	// internal/services/OCR/Backend) to run every page's OCR against,
+	// overriding the service's configured default.
+	Backend string
+
+	// Preprocessing applied to each rendered page before OCR - see
+	// internal/services/Image/Preprocess. Deskew/Denoise/Binarize all
+	// default to off; Upscale <= 1 means no upscaling.
+	Deskew   bool
+	Binarize string
+	Denoise  bool
+	Upscale  float64
+
+	// Progress, if set, is called after each page finishes OCR with the
+	// number of pages completed and the document's total page count - the
+	// same ticker/progress-bar shape long-running CLI tools use.
+	Progress func(pageDone, pageTotal int)
+}
+
+// DefaultProcessPDFOptions mirrors the defaults NewService used to bake in.
+var DefaultProcessPDFOptions = ProcessPDFOptions{
+	ConfidenceThreshold: 0.5,
+	MergeThreshold:      30,
+	Language:            "kor+eng",
+	SuryaEndpoint:       "http://localhost:6004",
+	DPI:                 150.0,
+}
+
+// ProcessPDFOption mutates a ProcessPDFOptions being built up.
+type ProcessPDFOption func(*ProcessPDFOptions)
+
+// WithConfidenceThreshold sets ConfidenceThreshold, passed on to
+// ExtractBlocks, which does not filter blocks by it.
+func WithConfidenceThreshold(v float64) ProcessPDFOption {
+	return func(o *ProcessPDFOptions) { o.ConfidenceThreshold = v }
+}
+
+// WithMergeBlocks sets MergeBlocks, passed on to ExtractBlocks, which does
+// not merge blocks.
+func WithMergeBlocks(v bool) ProcessPDFOption {
+	return func(o *ProcessPDFOptions) { o.MergeBlocks = v }
+}
+
+// WithMergeThreshold sets MergeThreshold, passed on to ExtractBlocks, which
+// does not merge blocks, so it has no effect.
+func WithMergeThreshold(v int) ProcessPDFOption {
+	return func(o *ProcessPDFOptions) { o.MergeThreshold = v }
+}
+
+// WithLanguage sets the OCR language hint (e.g. "kor+eng").
+func WithLanguage(v string) ProcessPDFOption {
+	return func(o *ProcessPDFOptions) { o.Language = v }
+}
+
+// WithSuryaEndpoint overrides the Surya base URL for this call only.
+func WithSuryaEndpoint(v string) ProcessPDFOption {
+	return func(o *ProcessPDFOptions) { o.SuryaEndpoint = v }
+}
+
+// WithDPI overrides the rasterization DPI used when rendering PDF pages.
+func WithDPI(v float64) ProcessPDFOption {
+	return func(o *ProcessPDFOptions) { o.DPI = v }
+}
+
+// WithContext attaches a deadline/cancellation context that overrides the
+// ctx passed positionally to Execute, so callers can scope a single PDF run.
+func WithContext(ctx context.Context) ProcessPDFOption {
+	return func(o *ProcessPDFOptions) { o.Context = ctx }
+}
+
+// WithBackend selects a registered OCR backend by name (e.g. "surya",
+// "aws_textract") for every page of this run, overriding the service's
+// default.
+func WithBackend(name string) ProcessPDFOption {
+	return func(o *ProcessPDFOptions) { o.Backend = name }
+}
+
+// WithDeskew enables Hough-style deskew before OCR on every page.
+func WithDeskew(v bool) ProcessPDFOption {
+	return func(o *ProcessPDFOptions) { o.Deskew = v }
+}
+
+// WithBinarize selects an adaptive-threshold method ("otsu" or "sauvola")
+// to apply before OCR on every page; "" skips binarization.
+func WithBinarize(v string) ProcessPDFOption {
+	return func(o *ProcessPDFOptions) { o.Binarize = v }
+}
+
+// WithDenoise enables morphological despeckling before OCR on every page.
+func WithDenoise(v bool) ProcessPDFOption {
+	return func(o *ProcessPDFOptions) { o.Denoise = v }
+}
+
+// WithUpscale sets the scale factor applied after the rest of the
+// preprocessing pipeline, e.g. 2 for 2x; <=1 skips upscaling.
+func WithUpscale(v float64) ProcessPDFOption {
+	return func(o *ProcessPDFOptions) { o.Upscale = v }
+}
+
+// Preprocessing reports whether any preprocessing step was requested.
+func (o ProcessPDFOptions) Preprocessing() bool {
+	return o.Deskew || o.Binarize != "" || o.Denoise || o.Upscale > 1
+}
+
+// WithProgress registers a callback invoked after every page finishes OCR,
+// so long-running callers (the job queue's SSE stream, a CLI progress bar)
+// can report progress without Execute buffering the whole result first.
+func WithProgress(fn func(pageDone, pageTotal int)) ProcessPDFOption {
+	return func(o *ProcessPDFOptions) { o.Progress = fn }
+}
+
+// WithOCROptions bridges the legacy models.OCROptions bag - still decoded
+// straight off multipart uploads in cmd/server - into the functional
+// options API without callers having to unpack each field by hand.
+func WithOCROptions(legacy models.OCROptions) ProcessPDFOption {
+	return func(o *ProcessPDFOptions) {
+		o.ConfidenceThreshold = legacy.ConfidenceThreshold
+		o.MergeBlocks = legacy.MergeBlocks
+		o.MergeThreshold = legacy.MergeThreshold
+		o.Language = legacy.Language
+		o.Deskew = legacy.Deskew
+		o.Binarize = legacy.Binarize
+		o.Denoise = legacy.Denoise
+		o.Upscale = legacy.Upscale
+	}
+}
+
+// Apply fills any zero-valued field of o with the matching field from
+// defaults, centralizing fallback logic instead of spreading it across
+// NewService and the HTTP handlers.
+func (o ProcessPDFOptions) Apply(defaults ProcessPDFOptions) ProcessPDFOptions {
+	if o.ConfidenceThreshold == 0 {
+		o.ConfidenceThreshold = defaults.ConfidenceThreshold
+	}
+	if o.MergeThreshold == 0 {
+		o.MergeThreshold = defaults.MergeThreshold
+	}
+	if o.Language == "" {
+		o.Language = defaults.Language
+	}
+	if o.SuryaEndpoint == "" {
+		o.SuryaEndpoint = defaults.SuryaEndpoint
+	}
+	if o.DPI == 0 {
+		o.DPI = defaults.DPI
+	}
+	if o.Backend == "" {
+		o.Backend = defaults.Backend
+	}
+	if o.Context == nil {
+		o.Context = defaults.Context
+	}
+	return o
+}
+
+// resolveProcessPDFOptions applies opts over the zero value and fills any
+// gaps from the service's own defaults (DPI/Language/Backend come from
+// NewServiceWithBackend, everything else from DefaultProcessPDFOptions).
+func resolveProcessPDFOptions(defaults ProcessPDFOptions, opts ...ProcessPDFOption) ProcessPDFOptions {
+	var o ProcessPDFOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o.Apply(defaults)
+}
@@ -3,15 +3,15 @@ package ListPages
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"os"
-	"path/filepath"
 
 	"github.com/plobin/genkitgo/internal/models"
+	"github.com/plobin/genkitgo/internal/storage"
 )
 
 type Service struct {
-	baseDir string
+	store storage.Storage
 }
 
 type PageSummary struct {
@@ -25,17 +25,14 @@ type PagesListResult struct {
 	Pages      []PageSummary `json:"pages"`
 }
 
-func NewService(baseDir string) *Service {
-	return &Service{
-		baseDir: baseDir,
-	}
+func NewService(store storage.Storage) *Service {
+	return &Service{store: store}
 }
 
 // Execute lists all pages for a request
 func (s *Service) Execute(ctx context.Context, requestID string) (*PagesListResult, error) {
 	// Load request metadata
-	metadataPath := filepath.Join(s.baseDir, requestID, "metadata.json")
-	data, err := os.ReadFile(metadataPath)
+	data, err := s.store.Get(ctx, requestID+"/metadata.json")
 	if err != nil {
 		return nil, fmt.Errorf("request not found: %w", err)
 	}
@@ -53,9 +50,11 @@ func (s *Service) Execute(ctx context.Context, requestID string) (*PagesListResu
 
 	// Iterate through all pages
 	for i := 1; i <= metadata.TotalPages; i++ {
-		pagePath := filepath.Join(s.baseDir, requestID, fmt.Sprintf("page_%d.json", i))
-		pageData, err := os.ReadFile(pagePath)
+		pageData, err := s.store.Get(ctx, fmt.Sprintf("%s/page_%d.json", requestID, i))
 		if err != nil {
+			if !errors.Is(err, storage.ErrNotExist) {
+				return nil, fmt.Errorf("failed to read page %d: %w", i, err)
+			}
 			// Page file might not exist yet
 			result.Pages = append(result.Pages, PageSummary{
 				PageNumber: i,
@@ -0,0 +1,124 @@
+package ApplyTemplate
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/plobin/genkitgo/internal/models"
+	"github.com/plobin/genkitgo/internal/services/OCR/Store"
+	"github.com/plobin/genkitgo/internal/services/Template/FieldMatch"
+	"github.com/plobin/genkitgo/internal/services/Template/GetTemplate"
+)
+
+type Service struct {
+	getTemplateService *GetTemplate.Service
+	store              *Store.Service
+}
+
+func NewService(getTemplateService *GetTemplate.Service, store *Store.Service) *Service {
+	return &Service{getTemplateService: getTemplateService, store: store}
+}
+
+// Execute resolves every field of templateID against the blocks indexed
+// for req.RequestID (one page if req.PageNumber is set, every page
+// otherwise), returning a value per matched field and the names of fields
+// that couldn't be resolved to any block.
+func (s *Service) Execute(ctx context.Context, templateID string, req models.TemplateApplyRequest) (*models.TemplateApplyResult, error) {
+	if req.RequestID == "" {
+		return nil, fmt.Errorf("request_id is required")
+	}
+
+	template, err := s.getTemplateService.Execute(ctx, templateID)
+	if err != nil {
+		return nil, err
+	}
+
+	pages, err := s.loadPages(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.TemplateApplyResult{Fields: make(map[string]models.TemplateFieldResult)}
+	for _, field := range template.Fields {
+		matched, ok := s.resolveField(field, pages)
+		if !ok {
+			result.Unmatched = append(result.Unmatched, field.Name)
+			continue
+		}
+
+		raw := FieldMatch.ConcatText(matched)
+		value, err := FieldMatch.Coerce(field, raw)
+		if err != nil {
+			result.Unmatched = append(result.Unmatched, field.Name)
+			continue
+		}
+
+		result.Fields[field.Name] = models.TemplateFieldResult{
+			Value:            value,
+			Raw:              raw,
+			Confidence:       averageConfidence(matched),
+			SourceBlockIDs:   blockIDs(matched),
+			ValidationErrors: FieldMatch.Validate(field, value),
+		}
+	}
+
+	return result, nil
+}
+
+// resolveField tries every loaded page in page-number order and returns the
+// first one whose blocks satisfy field - a field is expected to live on
+// exactly one page of a multi-page document. BlockIDs, when the template
+// author set them, are matched before falling back to Region/AnchorText, so
+// an explicitly authored field doesn't get silently re-resolved by geometry.
+func (s *Service) resolveField(field models.TemplateField, pages map[int][]models.BlockInfo) ([]models.BlockInfo, bool) {
+	pageNumbers := make([]int, 0, len(pages))
+	for pageNumber := range pages {
+		pageNumbers = append(pageNumbers, pageNumber)
+	}
+	sort.Ints(pageNumbers)
+
+	for _, pageNumber := range pageNumbers {
+		blocks := pages[pageNumber]
+		if matched := FieldMatch.SelectBlocksByID(field, blocks); len(matched) > 0 {
+			return matched, true
+		}
+	}
+	for _, pageNumber := range pageNumbers {
+		blocks := pages[pageNumber]
+		if matched := FieldMatch.SelectBlocks(field, blocks); len(matched) > 0 {
+			return matched, true
+		}
+	}
+	return nil, false
+}
+
+func (s *Service) loadPages(ctx context.Context, req models.TemplateApplyRequest) (map[int][]models.BlockInfo, error) {
+	if req.PageNumber != nil {
+		page, err := s.store.GetPage(ctx, req.RequestID, *req.PageNumber)
+		if err != nil {
+			return nil, err
+		}
+		return map[int][]models.BlockInfo{*req.PageNumber: page.Blocks}, nil
+	}
+	return s.store.GetRequestBlocks(ctx, req.RequestID)
+}
+
+func averageConfidence(blocks []models.BlockInfo) float64 {
+	if len(blocks) == 0 {
+		return 0
+	}
+	sum := 0.0
+	for _, b := range blocks {
+		sum += b.Confidence
+	}
+	return sum / float64(len(blocks))
+}
+
+func blockIDs(blocks []models.BlockInfo) []int {
+	ids := make([]int, len(blocks))
+	for i, b := range blocks {
+		ids[i] = b.ID
+	}
+	return ids
+}
@@ -4,24 +4,19 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"os"
-	"path/filepath"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/plobin/genkitgo/internal/models"
+	"github.com/plobin/genkitgo/internal/storage"
 )
 
 type Service struct {
-	baseDir string
+	store storage.Storage
 }
 
-func NewService(baseDir string) *Service {
-	templatesDir := filepath.Join(baseDir, "templates")
-	os.MkdirAll(templatesDir, 0755)
-	return &Service{
-		baseDir: templatesDir,
-	}
+func NewService(store storage.Storage) *Service {
+	return &Service{store: store}
 }
 
 // Execute creates a new template
@@ -43,14 +38,13 @@ func (s *Service) Execute(ctx context.Context, req models.TemplateCreateRequest)
 		UpdatedAt:   time.Now(),
 	}
 
-	// Save template to file
-	templatePath := filepath.Join(s.baseDir, template.ID+".json")
+	// Save template to storage
 	data, err := json.MarshalIndent(template, "", "  ")
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal template: %w", err)
 	}
 
-	if err := os.WriteFile(templatePath, data, 0644); err != nil {
+	if err := s.store.Put(ctx, "templates/"+template.ID+".json", data); err != nil {
 		return nil, fmt.Errorf("failed to save template: %w", err)
 	}
 
@@ -2,35 +2,32 @@ package DeleteTemplate
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"os"
-	"path/filepath"
+
+	"github.com/plobin/genkitgo/internal/storage"
 )
 
 type Service struct {
-	baseDir string
+	store storage.Storage
 }
 
-func NewService(baseDir string) *Service {
-	templatesDir := filepath.Join(baseDir, "templates")
-	os.MkdirAll(templatesDir, 0755)
-	return &Service{
-		baseDir: templatesDir,
-	}
+func NewService(store storage.Storage) *Service {
+	return &Service{store: store}
 }
 
 // Execute deletes a template by ID
 func (s *Service) Execute(ctx context.Context, templateID string) error {
-	templatePath := filepath.Join(s.baseDir, templateID+".json")
+	key := "templates/" + templateID + ".json"
 
-	if _, err := os.Stat(templatePath); err != nil {
-		if os.IsNotExist(err) {
+	if _, err := s.store.Stat(ctx, key); err != nil {
+		if errors.Is(err, storage.ErrNotExist) {
 			return fmt.Errorf("template not found: %s", templateID)
 		}
 		return fmt.Errorf("failed to check template: %w", err)
 	}
 
-	if err := os.Remove(templatePath); err != nil {
+	if err := s.store.Delete(ctx, key); err != nil {
 		return fmt.Errorf("failed to delete template: %w", err)
 	}
 
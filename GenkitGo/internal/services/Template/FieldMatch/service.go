@@ -0,0 +1,377 @@
+// Package FieldMatch holds the geometry and text-matching helpers shared
+// by ApplyTemplate (resolve a field's blocks on an OCR'd page) and
+// LearnTemplate (back-solve a field's region from an example value). It
+// is stateless, so unlike the other Template/* packages it has no
+// constructor - callers just call the package functions directly.
+package FieldMatch
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/plobin/genkitgo/internal/models"
+)
+
+// PageBounds approximates a page's pixel dimensions as the bounding extent
+// of its blocks, since the OCR index does not separately record page
+// width/height.
+func PageBounds(blocks []models.BlockInfo) (width, height int) {
+	for _, b := range blocks {
+		if right := b.BBox.X + b.BBox.Width; right > width {
+			width = right
+		}
+		if bottom := b.BBox.Y + b.BBox.Height; bottom > height {
+			height = bottom
+		}
+	}
+	return width, height
+}
+
+// intersects reports whether a normalized region, denormalized against a
+// page of size pageW x pageH, overlaps bbox.
+func intersects(region models.NormalizedBBox, pageW, pageH int, bbox models.BBox) bool {
+	rx := region.X * float64(pageW)
+	ry := region.Y * float64(pageH)
+	rw := region.Width * float64(pageW)
+	rh := region.Height * float64(pageH)
+
+	bx, by := float64(bbox.X), float64(bbox.Y)
+	bw, bh := float64(bbox.Width), float64(bbox.Height)
+
+	return rx < bx+bw && bx < rx+rw && ry < by+bh && by < ry+rh
+}
+
+// SelectBlocksByID returns field's BlockIDs blocks, in the order BlockIDs
+// lists them, or nil if field has no BlockIDs or none of them are present
+// among blocks.
+func SelectBlocksByID(field models.TemplateField, blocks []models.BlockInfo) []models.BlockInfo {
+	if len(field.BlockIDs) == 0 {
+		return nil
+	}
+
+	byID := make(map[int]models.BlockInfo, len(blocks))
+	for _, b := range blocks {
+		byID[b.ID] = b
+	}
+
+	var matched []models.BlockInfo
+	for _, id := range field.BlockIDs {
+		if b, ok := byID[id]; ok {
+			matched = append(matched, b)
+		}
+	}
+	return matched
+}
+
+// SelectBlocks resolves field's Region (or AnchorText+Offset) against the
+// blocks of the single page they belong to, and returns every block that
+// overlaps it. It returns nil if the field has neither a Region nor an
+// AnchorText, or an anchor can't be resolved.
+func SelectBlocks(field models.TemplateField, blocks []models.BlockInfo) []models.BlockInfo {
+	pageW, pageH := PageBounds(blocks)
+	if pageW == 0 || pageH == 0 {
+		return nil
+	}
+
+	region := field.Region
+	if region == nil {
+		if field.AnchorText == "" {
+			return nil
+		}
+		anchor := BestMatch(field.AnchorText, blocks)
+		if anchor == nil {
+			return nil
+		}
+		offset := models.NormalizedBBox{}
+		if field.Offset != nil {
+			offset = *field.Offset
+		}
+		region = &models.NormalizedBBox{
+			X:      float64(anchor.BBox.X)/float64(pageW) + offset.X,
+			Y:      float64(anchor.BBox.Y)/float64(pageH) + offset.Y,
+			Width:  offset.Width,
+			Height: offset.Height,
+		}
+	}
+
+	var matched []models.BlockInfo
+	for _, b := range blocks {
+		if intersects(*region, pageW, pageH, b.BBox) {
+			matched = append(matched, b)
+		}
+	}
+	return matched
+}
+
+// similarityThreshold is the minimum Similarity score for BestMatch to
+// consider a block a match for an anchor or example value, rather than
+// unrelated text that happens to share a few characters.
+const similarityThreshold = 0.6
+
+// BestMatch returns the block whose text most closely resembles want, or
+// nil if nothing clears similarityThreshold.
+func BestMatch(want string, blocks []models.BlockInfo) *models.BlockInfo {
+	var best *models.BlockInfo
+	bestScore := similarityThreshold
+	for i := range blocks {
+		if score := Similarity(want, blocks[i].Text); score >= bestScore {
+			best = &blocks[i]
+			bestScore = score
+		}
+	}
+	return best
+}
+
+// Similarity scores two strings 0..1 by normalized Levenshtein distance,
+// case- and whitespace-insensitive.
+func Similarity(a, b string) float64 {
+	a = strings.ToLower(strings.TrimSpace(a))
+	b = strings.ToLower(strings.TrimSpace(b))
+	if a == b {
+		return 1
+	}
+	ra, rb := []rune(a), []rune(b)
+	maxLen := len(ra)
+	if len(rb) > maxLen {
+		maxLen = len(rb)
+	}
+	if maxLen == 0 {
+		return 1
+	}
+	return 1 - float64(levenshtein(ra, rb))/float64(maxLen)
+}
+
+func levenshtein(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min3(curr[j-1]+1, prev[j]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
+
+func min3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+	return a
+}
+
+// ConcatText joins matched blocks' text in reading order (top-to-bottom,
+// then left-to-right), the way a human would read them off the page.
+func ConcatText(blocks []models.BlockInfo) string {
+	ordered := make([]models.BlockInfo, len(blocks))
+	copy(ordered, blocks)
+	sort.Slice(ordered, func(i, j int) bool {
+		if ordered[i].BBox.Y != ordered[j].BBox.Y {
+			return ordered[i].BBox.Y < ordered[j].BBox.Y
+		}
+		return ordered[i].BBox.X < ordered[j].BBox.X
+	})
+
+	parts := make([]string, len(ordered))
+	for i, b := range ordered {
+		parts[i] = strings.TrimSpace(b.Text)
+	}
+	return strings.TrimSpace(strings.Join(parts, " "))
+}
+
+// Coerce converts raw text into field's declared Type ("date", "money",
+// "int", "enum", or anything else treated as plain text), applying Regex
+// first when set.
+func Coerce(field models.TemplateField, raw string) (string, error) {
+	if field.Regex != "" {
+		re, err := regexp.Compile(field.Regex)
+		if err != nil {
+			return "", fmt.Errorf("invalid regex for field %q: %w", field.Name, err)
+		}
+		m := re.FindStringSubmatch(raw)
+		if m == nil {
+			return "", fmt.Errorf("value %q did not match regex for field %q", raw, field.Name)
+		}
+		if len(m) > 1 {
+			raw = m[1]
+		} else {
+			raw = m[0]
+		}
+	}
+
+	switch field.Type {
+	case "date":
+		return coerceDate(raw)
+	case "money":
+		return coerceMoney(raw)
+	case "int":
+		return coerceInt(raw)
+	case "enum":
+		return coerceEnum(raw, field.EnumValues)
+	default:
+		return strings.TrimSpace(raw), nil
+	}
+}
+
+var dateLayouts = []string{
+	"2006-01-02",
+	"2006/01/02",
+	"2006.01.02",
+	"01/02/2006",
+	"02-01-2006",
+	"Jan 2, 2006",
+	"January 2, 2006",
+}
+
+func coerceDate(raw string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, raw); err == nil {
+			return t.Format("2006-01-02"), nil
+		}
+	}
+	return "", fmt.Errorf("could not parse %q as a date", raw)
+}
+
+func coerceMoney(raw string) (string, error) {
+	cleaned := strings.Map(func(r rune) rune {
+		switch {
+		case r >= '0' && r <= '9', r == '.', r == '-':
+			return r
+		default:
+			return -1
+		}
+	}, raw)
+	value, err := strconv.ParseFloat(cleaned, 64)
+	if err != nil {
+		return "", fmt.Errorf("could not parse %q as money: %w", raw, err)
+	}
+	return strconv.FormatFloat(value, 'f', 2, 64), nil
+}
+
+func coerceInt(raw string) (string, error) {
+	cleaned := strings.Map(func(r rune) rune {
+		if (r >= '0' && r <= '9') || r == '-' {
+			return r
+		}
+		return -1
+	}, raw)
+	value, err := strconv.Atoi(cleaned)
+	if err != nil {
+		return "", fmt.Errorf("could not parse %q as an int: %w", raw, err)
+	}
+	return strconv.Itoa(value), nil
+}
+
+func coerceEnum(raw string, values []string) (string, error) {
+	normalized := strings.ToLower(strings.TrimSpace(raw))
+	for _, v := range values {
+		if strings.ToLower(v) == normalized {
+			return v, nil
+		}
+	}
+	return "", fmt.Errorf("%q is not one of the allowed enum values", raw)
+}
+
+// Validate checks a field's coerced value against its Validation rules - a
+// comma-separated list of "required", "min:<n>", "max:<n>", and
+// "regex:<pattern>" - and returns one message per failing rule.
+func Validate(field models.TemplateField, value string) []string {
+	var errs []string
+	if field.Validation == "" {
+		return errs
+	}
+
+	for _, rule := range strings.Split(field.Validation, ",") {
+		rule = strings.TrimSpace(rule)
+		if rule == "" {
+			continue
+		}
+		name, arg, _ := strings.Cut(rule, ":")
+
+		switch name {
+		case "required":
+			if strings.TrimSpace(value) == "" {
+				errs = append(errs, fmt.Sprintf("field %q is required", field.Name))
+			}
+		case "regex":
+			re, err := regexp.Compile(arg)
+			if err != nil {
+				errs = append(errs, fmt.Sprintf("field %q has an invalid validation regex: %v", field.Name, err))
+				continue
+			}
+			if !re.MatchString(value) {
+				errs = append(errs, fmt.Sprintf("field %q value %q does not match %s", field.Name, value, arg))
+			}
+		case "min", "max":
+			n, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				errs = append(errs, fmt.Sprintf("field %q value %q is not numeric", field.Name, value))
+				continue
+			}
+			bound, err := strconv.ParseFloat(arg, 64)
+			if err != nil {
+				errs = append(errs, fmt.Sprintf("field %q has an invalid %s bound %q", field.Name, name, arg))
+				continue
+			}
+			if name == "min" && n < bound {
+				errs = append(errs, fmt.Sprintf("field %q value %v is below minimum %v", field.Name, n, bound))
+			}
+			if name == "max" && n > bound {
+				errs = append(errs, fmt.Sprintf("field %q value %v exceeds maximum %v", field.Name, n, bound))
+			}
+		default:
+			errs = append(errs, fmt.Sprintf("field %q has unknown validation rule %q", field.Name, name))
+		}
+	}
+
+	return errs
+}
+
+// NormalizeRegion computes the normalized bounding box that tightly
+// encloses blocks on a page of size pageW x pageH - the inverse of
+// SelectBlocks, used to back-solve a field's Region from an example.
+func NormalizeRegion(blocks []models.BlockInfo, pageW, pageH int) models.NormalizedBBox {
+	if len(blocks) == 0 || pageW == 0 || pageH == 0 {
+		return models.NormalizedBBox{}
+	}
+
+	minX, minY := blocks[0].BBox.X, blocks[0].BBox.Y
+	maxX, maxY := blocks[0].BBox.X+blocks[0].BBox.Width, blocks[0].BBox.Y+blocks[0].BBox.Height
+	for _, b := range blocks[1:] {
+		if b.BBox.X < minX {
+			minX = b.BBox.X
+		}
+		if b.BBox.Y < minY {
+			minY = b.BBox.Y
+		}
+		if right := b.BBox.X + b.BBox.Width; right > maxX {
+			maxX = right
+		}
+		if bottom := b.BBox.Y + b.BBox.Height; bottom > maxY {
+			maxY = bottom
+		}
+	}
+
+	return models.NormalizedBBox{
+		X:      float64(minX) / float64(pageW),
+		Y:      float64(minY) / float64(pageH),
+		Width:  float64(maxX-minX) / float64(pageW),
+		Height: float64(maxY-minY) / float64(pageH),
+	}
+}
@@ -0,0 +1,121 @@
+package FieldMatch
+
+import (
+	"testing"
+
+	"github.com/plobin/genkitgo/internal/models"
+)
+
+func blocksByIDFixture() []models.BlockInfo {
+	return []models.BlockInfo{
+		{ID: 1, Text: "Invoice"},
+		{ID: 2, Text: "Number: INV-1001"},
+		{ID: 3, Text: "Total: 42.00"},
+	}
+}
+
+func TestSelectBlocksByIDReturnsInFieldOrderIgnoringMissingIDs(t *testing.T) {
+	field := models.TemplateField{BlockIDs: []int{3, 1, 99}}
+	matched := SelectBlocksByID(field, blocksByIDFixture())
+
+	if len(matched) != 2 {
+		t.Fatalf("expected 2 matched blocks, got %d: %+v", len(matched), matched)
+	}
+	if matched[0].ID != 3 || matched[1].ID != 1 {
+		t.Fatalf("expected blocks in BlockIDs order [3, 1], got [%d, %d]", matched[0].ID, matched[1].ID)
+	}
+}
+
+func TestSelectBlocksByIDReturnsNilWithoutBlockIDs(t *testing.T) {
+	field := models.TemplateField{}
+	if matched := SelectBlocksByID(field, blocksByIDFixture()); matched != nil {
+		t.Fatalf("expected nil, got %+v", matched)
+	}
+}
+
+func pageFixture() []models.BlockInfo {
+	return []models.BlockInfo{
+		{ID: 1, Text: "Invoice Number", BBox: models.BBox{X: 0, Y: 0, Width: 100, Height: 20}},
+		{ID: 2, Text: "INV-1001", BBox: models.BBox{X: 0, Y: 20, Width: 100, Height: 20}},
+		{ID: 3, Text: "Total", BBox: models.BBox{X: 0, Y: 100, Width: 100, Height: 20}},
+	}
+}
+
+func TestSelectBlocksByRegion(t *testing.T) {
+	blocks := pageFixture()
+	field := models.TemplateField{
+		Region: &models.NormalizedBBox{X: 0, Y: 0, Width: 1, Height: 0.4},
+	}
+
+	matched := SelectBlocks(field, blocks)
+	if len(matched) != 2 {
+		t.Fatalf("expected 2 blocks inside the region, got %d: %+v", len(matched), matched)
+	}
+}
+
+func TestSelectBlocksByAnchorText(t *testing.T) {
+	blocks := pageFixture()
+	field := models.TemplateField{
+		AnchorText: "Invoice Number",
+		Offset:     &models.NormalizedBBox{X: 0, Y: 0.2, Width: 1, Height: 0.2},
+	}
+
+	matched := SelectBlocks(field, blocks)
+	if len(matched) != 1 || matched[0].ID != 2 {
+		t.Fatalf("expected anchor offset to match block 2, got %+v", matched)
+	}
+}
+
+func TestCoerce(t *testing.T) {
+	tests := []struct {
+		name    string
+		field   models.TemplateField
+		raw     string
+		want    string
+		wantErr bool
+	}{
+		{name: "date", field: models.TemplateField{Type: "date"}, raw: "2024-01-02", want: "2024-01-02"},
+		{name: "date us format", field: models.TemplateField{Type: "date"}, raw: "01/02/2024", want: "2024-01-02"},
+		{name: "money", field: models.TemplateField{Type: "money"}, raw: "$1,234.5", want: "1234.50"},
+		{name: "int", field: models.TemplateField{Type: "int"}, raw: "  42 units", want: "42"},
+		{name: "enum match", field: models.TemplateField{Type: "enum", EnumValues: []string{"Paid", "Unpaid"}}, raw: "paid", want: "Paid"},
+		{name: "enum no match", field: models.TemplateField{Type: "enum", EnumValues: []string{"Paid"}}, raw: "Pending", wantErr: true},
+		{name: "text", field: models.TemplateField{}, raw: "  plain text  ", want: "plain text"},
+		{name: "regex capture group", field: models.TemplateField{Regex: `INV-(\d+)`}, raw: "Number: INV-1001", want: "1001"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Coerce(tt.field, tt.raw)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got value %q", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Coerce: %v", err)
+			}
+			if got != tt.want {
+				t.Fatalf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	field := models.TemplateField{Name: "amount", Validation: "required,min:10,max:100"}
+
+	if errs := Validate(field, "50"); len(errs) != 0 {
+		t.Fatalf("expected no validation errors, got %v", errs)
+	}
+	if errs := Validate(field, ""); len(errs) == 0 {
+		t.Fatal("expected a required-field validation error")
+	}
+	if errs := Validate(field, "5"); len(errs) == 0 {
+		t.Fatal("expected a below-minimum validation error")
+	}
+	if errs := Validate(field, "500"); len(errs) == 0 {
+		t.Fatal("expected an above-maximum validation error")
+	}
+}
@@ -3,31 +3,26 @@ package GetTemplate
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"os"
-	"path/filepath"
 
 	"github.com/plobin/genkitgo/internal/models"
+	"github.com/plobin/genkitgo/internal/storage"
 )
 
 type Service struct {
-	baseDir string
+	store storage.Storage
 }
 
-func NewService(baseDir string) *Service {
-	templatesDir := filepath.Join(baseDir, "templates")
-	os.MkdirAll(templatesDir, 0755)
-	return &Service{
-		baseDir: templatesDir,
-	}
+func NewService(store storage.Storage) *Service {
+	return &Service{store: store}
 }
 
 // Execute retrieves a specific template by ID
 func (s *Service) Execute(ctx context.Context, templateID string) (*models.Template, error) {
-	templatePath := filepath.Join(s.baseDir, templateID+".json")
-	data, err := os.ReadFile(templatePath)
+	data, err := s.store.Get(ctx, "templates/"+templateID+".json")
 	if err != nil {
-		if os.IsNotExist(err) {
+		if errors.Is(err, storage.ErrNotExist) {
 			return nil, fmt.Errorf("template not found: %s", templateID)
 		}
 		return nil, fmt.Errorf("failed to read template: %w", err)
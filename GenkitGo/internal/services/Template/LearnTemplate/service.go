@@ -0,0 +1,67 @@
+package LearnTemplate
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/plobin/genkitgo/internal/models"
+	"github.com/plobin/genkitgo/internal/services/OCR/Store"
+	"github.com/plobin/genkitgo/internal/services/Template/CreateTemplate"
+	"github.com/plobin/genkitgo/internal/services/Template/FieldMatch"
+)
+
+type Service struct {
+	createTemplateService *CreateTemplate.Service
+	store                 *Store.Service
+}
+
+func NewService(createTemplateService *CreateTemplate.Service, store *Store.Service) *Service {
+	return &Service{createTemplateService: createTemplateService, store: store}
+}
+
+// Execute authors a template by example: for each name/value pair in
+// req.FieldValues, it finds the block on req.PageNumber whose text best
+// matches value and back-solves the field's Region from that block's
+// bbox, then persists the result the same way CreateTemplate does.
+func (s *Service) Execute(ctx context.Context, req models.TemplateLearnRequest) (*models.Template, error) {
+	if req.RequestID == "" {
+		return nil, fmt.Errorf("request_id is required")
+	}
+	if len(req.FieldValues) == 0 {
+		return nil, fmt.Errorf("field_values must have at least one entry")
+	}
+
+	page, err := s.store.GetPage(ctx, req.RequestID, req.PageNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	pageW, pageH := FieldMatch.PageBounds(page.Blocks)
+
+	var fields []models.TemplateField
+	var unresolved []string
+	for name, value := range req.FieldValues {
+		anchor := FieldMatch.BestMatch(value, page.Blocks)
+		if anchor == nil {
+			unresolved = append(unresolved, name)
+			continue
+		}
+		region := FieldMatch.NormalizeRegion([]models.BlockInfo{*anchor}, pageW, pageH)
+		fields = append(fields, models.TemplateField{
+			Name:     name,
+			Type:     "text",
+			Region:   &region,
+			BlockIDs: []int{anchor.ID},
+		})
+	}
+
+	if len(unresolved) > 0 {
+		return nil, fmt.Errorf("could not resolve field(s) %v to any block on page %d", unresolved, req.PageNumber)
+	}
+
+	return s.createTemplateService.Execute(ctx, models.TemplateCreateRequest{
+		Name:        req.Name,
+		Description: req.Description,
+		Fields:      fields,
+	})
+}
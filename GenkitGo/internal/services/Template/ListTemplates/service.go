@@ -3,39 +3,36 @@ package ListTemplates
 import (
 	"context"
 	"encoding/json"
-	"os"
-	"path/filepath"
+	"path"
 
 	"github.com/plobin/genkitgo/internal/models"
+	"github.com/plobin/genkitgo/internal/storage"
 )
 
+const templatesPrefix = "templates/"
+
 type Service struct {
-	baseDir string
+	store storage.Storage
 }
 
-func NewService(baseDir string) *Service {
-	templatesDir := filepath.Join(baseDir, "templates")
-	os.MkdirAll(templatesDir, 0755)
-	return &Service{
-		baseDir: templatesDir,
-	}
+func NewService(store storage.Storage) *Service {
+	return &Service{store: store}
 }
 
 // Execute lists all available templates
 func (s *Service) Execute(ctx context.Context) ([]models.Template, error) {
-	entries, err := os.ReadDir(s.baseDir)
+	infos, err := s.store.List(ctx, templatesPrefix)
 	if err != nil {
-		return []models.Template{}, nil // Return empty list if directory doesn't exist
+		return []models.Template{}, nil // Return empty list if the prefix doesn't exist
 	}
 
 	templates := make([]models.Template, 0)
-	for _, entry := range entries {
-		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+	for _, info := range infos {
+		if path.Ext(info.Key) != ".json" {
 			continue
 		}
 
-		templatePath := filepath.Join(s.baseDir, entry.Name())
-		data, err := os.ReadFile(templatePath)
+		data, err := s.store.Get(ctx, info.Key)
 		if err != nil {
 			continue
 		}
@@ -0,0 +1,223 @@
+package UploadStore
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Service stores uploaded files content-addressed by their sha256 digest
+// under baseDir/content/{sha256}/data, with an intermediate staging area
+// under baseDir/resumable/{id}/ for uploads still in progress.
+//
+// Two ingestion paths are supported: StreamStore hashes and stores a whole
+// upload in one pass (the multipart case), while Create/Append/Finalize
+// implement a tus-style protocol that lets a client resume a dropped
+// upload by PATCHing the remaining bytes at a known offset.
+type Service struct {
+	baseDir string
+}
+
+// NewService creates an upload store rooted at baseDir (typically
+// OutputDir/uploads).
+func NewService(baseDir string) *Service {
+	if baseDir == "" {
+		baseDir = "output/uploads"
+	}
+	os.MkdirAll(filepath.Join(baseDir, "resumable"), 0755)
+	os.MkdirAll(filepath.Join(baseDir, "content"), 0755)
+	os.MkdirAll(filepath.Join(baseDir, "tmp"), 0755)
+	return &Service{baseDir: baseDir}
+}
+
+func (s *Service) resumableDir(id string) string {
+	return filepath.Join(s.baseDir, "resumable", id)
+}
+
+func (s *Service) contentDir(sha256hex string) string {
+	return filepath.Join(s.baseDir, "content", sha256hex)
+}
+
+// Create starts a new resumable upload of the given total length and
+// returns its ID. The caller PATCHes chunks in via Append.
+func (s *Service) Create(length int64) (*Upload, error) {
+	id := uuid.New().String()
+	dir := s.resumableDir(id)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create upload directory: %w", err)
+	}
+
+	dataFile, err := os.Create(filepath.Join(dir, "data"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create upload data file: %w", err)
+	}
+	dataFile.Close()
+
+	now := time.Now()
+	upload := &Upload{
+		ID:        id,
+		Length:    length,
+		Offset:    0,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := s.saveMetadata(upload); err != nil {
+		return nil, err
+	}
+	return upload, nil
+}
+
+// Get returns the current state of a resumable upload.
+func (s *Service) Get(id string) (*Upload, error) {
+	data, err := os.ReadFile(filepath.Join(s.resumableDir(id), "metadata.json"))
+	if err != nil {
+		return nil, fmt.Errorf("upload not found: %w", err)
+	}
+	var upload Upload
+	if err := json.Unmarshal(data, &upload); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal upload metadata: %w", err)
+	}
+	return &upload, nil
+}
+
+// Append writes r to the upload's data file starting at offset, which must
+// match the upload's current offset (a tus-style conflict otherwise), and
+// returns the upload's new state.
+func (s *Service) Append(id string, offset int64, r io.Reader) (*Upload, error) {
+	upload, err := s.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	if offset != upload.Offset {
+		return nil, fmt.Errorf("offset mismatch: upload is at %d, got %d", upload.Offset, offset)
+	}
+
+	dataFile, err := os.OpenFile(filepath.Join(s.resumableDir(id), "data"), os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open upload data file: %w", err)
+	}
+	defer dataFile.Close()
+
+	if _, err := dataFile.Seek(offset, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("failed to seek upload data file: %w", err)
+	}
+
+	written, err := io.Copy(dataFile, r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write upload chunk: %w", err)
+	}
+
+	upload.Offset += written
+	upload.UpdatedAt = time.Now()
+	if upload.Offset >= upload.Length {
+		upload.Completed = true
+	}
+
+	if err := s.saveMetadata(upload); err != nil {
+		return nil, err
+	}
+	return upload, nil
+}
+
+// Finalize moves a completed resumable upload into the content-addressed
+// store, keyed by its sha256 digest, and returns the digest and final path.
+func (s *Service) Finalize(id string) (string, string, error) {
+	upload, err := s.Get(id)
+	if err != nil {
+		return "", "", err
+	}
+	if !upload.Completed {
+		return "", "", fmt.Errorf("upload %s is not complete (%d/%d bytes)", id, upload.Offset, upload.Length)
+	}
+
+	dataPath := filepath.Join(s.resumableDir(id), "data")
+	digest, err := hashFile(dataPath)
+	if err != nil {
+		return "", "", err
+	}
+
+	finalPath, err := s.adopt(dataPath, digest)
+	if err != nil {
+		return "", "", err
+	}
+
+	os.RemoveAll(s.resumableDir(id))
+	return digest, finalPath, nil
+}
+
+// StreamStore copies r straight into the content-addressed store while
+// computing its sha256 digest on the fly, for callers that already have a
+// single streaming reader (e.g. a multipart.Part) rather than a resumable
+// upload in progress.
+func (s *Service) StreamStore(r io.Reader) (string, string, int64, error) {
+	tmpFile, err := os.CreateTemp(filepath.Join(s.baseDir, "tmp"), "upload-*")
+	if err != nil {
+		return "", "", 0, fmt.Errorf("failed to create temp file: %w", err)
+	}
+	tmpPath := tmpFile.Name()
+	defer os.Remove(tmpPath) // no-op once adopt renames it away
+
+	hasher := sha256.New()
+	size, err := io.Copy(io.MultiWriter(tmpFile, hasher), r)
+	tmpFile.Close()
+	if err != nil {
+		return "", "", 0, fmt.Errorf("failed to stream upload: %w", err)
+	}
+
+	digest := hex.EncodeToString(hasher.Sum(nil))
+	finalPath, err := s.adopt(tmpPath, digest)
+	if err != nil {
+		return "", "", 0, err
+	}
+	return digest, finalPath, size, nil
+}
+
+// adopt moves srcPath into the content-addressed directory for digest,
+// creating it if this is the first upload with that content.
+func (s *Service) adopt(srcPath, digest string) (string, error) {
+	dir := s.contentDir(digest)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create content directory: %w", err)
+	}
+
+	finalPath := filepath.Join(dir, "data")
+	if _, err := os.Stat(finalPath); err == nil {
+		// Identical content already stored - drop the duplicate.
+		os.Remove(srcPath)
+		return finalPath, nil
+	}
+
+	if err := os.Rename(srcPath, finalPath); err != nil {
+		return "", fmt.Errorf("failed to adopt uploaded file: %w", err)
+	}
+	return finalPath, nil
+}
+
+func (s *Service) saveMetadata(upload *Upload) error {
+	data, err := json.MarshalIndent(upload, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal upload metadata: %w", err)
+	}
+	return os.WriteFile(filepath.Join(s.resumableDir(upload.ID), "metadata.json"), data, 0644)
+}
+
+func hashFile(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to open upload data file: %w", err)
+	}
+	defer f.Close()
+
+	hasher := sha256.New()
+	if _, err := io.Copy(hasher, f); err != nil {
+		return "", fmt.Errorf("failed to hash upload data file: %w", err)
+	}
+	return hex.EncodeToString(hasher.Sum(nil)), nil
+}
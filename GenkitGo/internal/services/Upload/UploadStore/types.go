@@ -0,0 +1,15 @@
+package UploadStore
+
+import "time"
+
+// Upload is the persisted state of one tus-style resumable upload. It is
+// written to baseDir/resumable/{id}/metadata.json on every append so a
+// client can resume after a dropped connection.
+type Upload struct {
+	ID        string    `json:"id"`
+	Length    int64     `json:"length"`
+	Offset    int64     `json:"offset"`
+	Completed bool      `json:"completed"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
@@ -0,0 +1,114 @@
+package CropBlocks
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/png"
+	"os"
+	"path/filepath"
+
+	xdraw "golang.org/x/image/draw"
+
+	"github.com/plobin/genkitgo/internal/models"
+)
+
+// Service emits per-block PNG crops and a downscaled page thumbnail,
+// persisted alongside the OCR index so downstream UIs don't need to
+// re-decode the source page for every region they want to show.
+type Service struct {
+	baseDir string
+}
+
+func NewService(baseDir string) *Service {
+	return &Service{baseDir: baseDir}
+}
+
+// BaseDir returns the directory this service writes thumbnails under, so
+// sibling services (ProcessPDF's preprocessing cache) can share the same
+// per-request layout without threading another config value through.
+func (s *Service) BaseDir() string {
+	return s.baseDir
+}
+
+// Execute crops every block out of srcImg and writes a max-dimension-bounded
+// thumbnail of the whole page, under
+// <baseDir>/<requestID>/thumbs/page_<pageNumber>/block_<id>.png and
+// .../page_<pageNumber>/thumbnail.png.
+func (s *Service) Execute(ctx context.Context, srcImg image.Image, requestID string, pageNumber int, blocks []models.BlockInfo, maxThumbDim int) error {
+	rgba := toRGBA(srcImg)
+	bounds := rgba.Bounds()
+
+	pageDir := filepath.Join(s.baseDir, requestID, "thumbs", fmt.Sprintf("page_%d", pageNumber))
+	if err := os.MkdirAll(pageDir, 0755); err != nil {
+		return fmt.Errorf("failed to create thumbs directory: %w", err)
+	}
+
+	for _, block := range blocks {
+		rect := image.Rect(block.BBox.X, block.BBox.Y, block.BBox.X+block.BBox.Width, block.BBox.Y+block.BBox.Height).Intersect(bounds)
+		if rect.Empty() {
+			continue
+		}
+
+		crop := rgba.SubImage(rect)
+		cropPath := filepath.Join(pageDir, fmt.Sprintf("block_%d.png", block.ID))
+		if err := writePNG(cropPath, crop); err != nil {
+			return fmt.Errorf("failed to write crop for block %d: %w", block.ID, err)
+		}
+	}
+
+	thumbnail := scaleToMaxDim(rgba, maxThumbDim)
+	if err := writePNG(filepath.Join(pageDir, "thumbnail.png"), thumbnail); err != nil {
+		return fmt.Errorf("failed to write page thumbnail: %w", err)
+	}
+
+	return nil
+}
+
+func toRGBA(src image.Image) *image.RGBA {
+	if rgba, ok := src.(*image.RGBA); ok {
+		return rgba
+	}
+	bounds := src.Bounds()
+	rgba := image.NewRGBA(bounds)
+	draw.Draw(rgba, bounds, src, bounds.Min, draw.Src)
+	return rgba
+}
+
+// scaleToMaxDim downscales img so neither dimension exceeds maxDim,
+// preserving aspect ratio. Images already within bounds are returned as-is.
+func scaleToMaxDim(img image.Image, maxDim int) image.Image {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if maxDim <= 0 || (width <= maxDim && height <= maxDim) {
+		return img
+	}
+
+	scale := float64(maxDim) / float64(width)
+	if s := float64(maxDim) / float64(height); s < scale {
+		scale = s
+	}
+
+	dstW := int(float64(width) * scale)
+	dstH := int(float64(height) * scale)
+	if dstW < 1 {
+		dstW = 1
+	}
+	if dstH < 1 {
+		dstH = 1
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dstW, dstH))
+	xdraw.BiLinear.Scale(dst, dst.Bounds(), img, bounds, xdraw.Over, nil)
+	return dst
+}
+
+func writePNG(path string, img image.Image) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	return png.Encode(file, img)
+}
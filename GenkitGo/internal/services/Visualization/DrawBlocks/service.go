@@ -21,8 +21,15 @@ func NewService() *Service {
 	return &Service{}
 }
 
-// Execute draws OCR blocks on the original image and saves as visualization.png
-func (s *Service) Execute(ctx context.Context, imagePath string, blocks []models.BlockInfo, outputPath string) error {
+// Execute draws OCR blocks on the original image and saves as
+// visualization.png. Options are resolved through the functional-options
+// API; see types_drawblocks_options.go.
+func (s *Service) Execute(ctx context.Context, imagePath string, blocks []models.BlockInfo, outputPath string, opts ...DrawBlocksOption) error {
+	options := resolveDrawBlocksOptions(opts...)
+	if options.Context != nil {
+		ctx = options.Context
+	}
+
 	// Load original image
 	file, err := os.Open(imagePath)
 	if err != nil {
@@ -0,0 +1,28 @@
+package DrawBlocks
+
+import "context"
+
+// DrawBlocksOptions centralizes the knobs Service.Execute accepts, mirroring
+// ExtractBlocksOptions and ProcessPDFOptions even though DrawBlocks itself
+// has little to configure today.
+type DrawBlocksOptions struct {
+	Context context.Context
+}
+
+// DrawBlocksOption mutates a DrawBlocksOptions being built up.
+type DrawBlocksOption func(*DrawBlocksOptions)
+
+// WithContext sets a context that replaces the ctx passed positionally to
+// Execute. Execute does not check either context for cancellation or a
+// deadline.
+func WithContext(ctx context.Context) DrawBlocksOption {
+	return func(o *DrawBlocksOptions) { o.Context = ctx }
+}
+
+func resolveDrawBlocksOptions(opts ...DrawBlocksOption) DrawBlocksOptions {
+	var o DrawBlocksOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
@@ -0,0 +1,49 @@
+package GetBlockCrop
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/plobin/genkitgo/internal/services/OCR/Store"
+)
+
+// CropResult is a single crop image ready to be streamed back over HTTP.
+type CropResult struct {
+	Data        []byte
+	ContentType string
+	ETag        string
+}
+
+type Service struct {
+	store   *Store.Service
+	baseDir string
+}
+
+func NewService(store *Store.Service, baseDir string) *Service {
+	return &Service{store: store, baseDir: baseDir}
+}
+
+// Execute resolves blockID to its (requestID, pageNumber) via the store and
+// reads the crop PNG that CropBlocks wrote for it.
+func (s *Service) Execute(ctx context.Context, blockID int) (*CropResult, error) {
+	requestID, pageNumber, err := s.store.GetBlockLocation(ctx, blockID)
+	if err != nil {
+		return nil, err
+	}
+
+	cropPath := filepath.Join(s.baseDir, requestID, "thumbs", fmt.Sprintf("page_%d", pageNumber), fmt.Sprintf("block_%d.png", blockID))
+	data, err := os.ReadFile(cropPath)
+	if err != nil {
+		return nil, fmt.Errorf("crop not found for block %d: %w", blockID, err)
+	}
+
+	hash := sha256.Sum256(data)
+	return &CropResult{
+		Data:        data,
+		ContentType: "image/png",
+		ETag:        fmt.Sprintf(`"%x"`, hash),
+	}, nil
+}
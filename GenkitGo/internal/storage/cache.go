@@ -0,0 +1,86 @@
+package storage
+
+import (
+	"context"
+	"sync"
+)
+
+// cacheEntry holds a cached Get result, or the fact that the key doesn't
+// exist, so a repeated miss doesn't keep hitting the underlying backend.
+type cacheEntry struct {
+	data   []byte
+	exists bool
+}
+
+// CachingStorage wraps a Storage with a read-through in-memory cache of
+// Get results, for a backend (typically S3) where repeated reads of the
+// same key - e.g. a template fetched on every ApplyTemplate call - are
+// more expensive than a local map lookup. Put and Delete made through this
+// wrapper invalidate the affected key. Entries never expire, so a write made
+// to the backend by anything else (e.g. another API instance) is not seen
+// for a key that is already cached.
+type CachingStorage struct {
+	backend Storage
+	mu      sync.RWMutex
+	cache   map[string]cacheEntry
+}
+
+// NewCachingStorage wraps backend with a read-through cache.
+func NewCachingStorage(backend Storage) *CachingStorage {
+	return &CachingStorage{backend: backend, cache: make(map[string]cacheEntry)}
+}
+
+func (c *CachingStorage) Get(ctx context.Context, key string) ([]byte, error) {
+	c.mu.RLock()
+	entry, ok := c.cache[key]
+	c.mu.RUnlock()
+	if ok {
+		if !entry.exists {
+			return nil, ErrNotExist
+		}
+		return entry.data, nil
+	}
+
+	data, err := c.backend.Get(ctx, key)
+	if err != nil {
+		if err == ErrNotExist {
+			c.mu.Lock()
+			c.cache[key] = cacheEntry{exists: false}
+			c.mu.Unlock()
+		}
+		return nil, err
+	}
+
+	c.mu.Lock()
+	c.cache[key] = cacheEntry{data: data, exists: true}
+	c.mu.Unlock()
+	return data, nil
+}
+
+func (c *CachingStorage) Put(ctx context.Context, key string, data []byte) error {
+	if err := c.backend.Put(ctx, key, data); err != nil {
+		return err
+	}
+	c.mu.Lock()
+	delete(c.cache, key)
+	c.mu.Unlock()
+	return nil
+}
+
+func (c *CachingStorage) Delete(ctx context.Context, key string) error {
+	if err := c.backend.Delete(ctx, key); err != nil {
+		return err
+	}
+	c.mu.Lock()
+	delete(c.cache, key)
+	c.mu.Unlock()
+	return nil
+}
+
+func (c *CachingStorage) List(ctx context.Context, prefix string) ([]Info, error) {
+	return c.backend.List(ctx, prefix)
+}
+
+func (c *CachingStorage) Stat(ctx context.Context, key string) (*Info, error) {
+	return c.backend.Stat(ctx, key)
+}
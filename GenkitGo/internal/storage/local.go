@@ -0,0 +1,108 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// LocalDriver implements Storage against the local filesystem, rooted at
+// baseDir - the original, single-instance behavior every service used to
+// hard-code directly.
+type LocalDriver struct {
+	baseDir string
+}
+
+// NewLocalDriver creates a LocalDriver rooted at baseDir, creating it if
+// it doesn't already exist.
+func NewLocalDriver(baseDir string) (*LocalDriver, error) {
+	if err := os.MkdirAll(baseDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create storage directory %s: %w", baseDir, err)
+	}
+	return &LocalDriver{baseDir: baseDir}, nil
+}
+
+func (d *LocalDriver) path(key string) string {
+	return filepath.Join(d.baseDir, filepath.FromSlash(key))
+}
+
+func (d *LocalDriver) Put(ctx context.Context, key string, data []byte) error {
+	p := d.path(key)
+	if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+		return fmt.Errorf("failed to create directory for %s: %w", key, err)
+	}
+	if err := os.WriteFile(p, data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", key, err)
+	}
+	return nil
+}
+
+func (d *LocalDriver) Get(ctx context.Context, key string) ([]byte, error) {
+	data, err := os.ReadFile(d.path(key))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrNotExist
+		}
+		return nil, fmt.Errorf("failed to read %s: %w", key, err)
+	}
+	return data, nil
+}
+
+func (d *LocalDriver) Delete(ctx context.Context, key string) error {
+	if err := os.Remove(d.path(key)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to delete %s: %w", key, err)
+	}
+	return nil
+}
+
+func (d *LocalDriver) List(ctx context.Context, prefix string) ([]Info, error) {
+	root := d.path(prefix)
+	var infos []Info
+
+	err := filepath.WalkDir(root, func(p string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if entry.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(d.baseDir, p)
+		if err != nil {
+			return err
+		}
+
+		fi, err := entry.Info()
+		if err != nil {
+			return err
+		}
+
+		infos = append(infos, Info{
+			Key:          filepath.ToSlash(rel),
+			Size:         fi.Size(),
+			LastModified: fi.ModTime(),
+		})
+		return nil
+	})
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to list %s: %w", prefix, err)
+	}
+
+	return infos, nil
+}
+
+func (d *LocalDriver) Stat(ctx context.Context, key string) (*Info, error) {
+	fi, err := os.Stat(d.path(key))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrNotExist
+		}
+		return nil, fmt.Errorf("failed to stat %s: %w", key, err)
+	}
+	return &Info{Key: key, Size: fi.Size(), LastModified: fi.ModTime()}, nil
+}
@@ -0,0 +1,30 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+)
+
+// Migrate copies every object under prefix from src to dst, key for key -
+// e.g. to move an existing local "output/" tree onto a newly provisioned
+// S3 bucket before cutting a deployment over to it. It returns the number
+// of objects copied; a read or write failure on any one key aborts the
+// whole migration rather than leaving it partially done silently.
+func Migrate(ctx context.Context, src, dst Storage, prefix string) (int, error) {
+	infos, err := src.List(ctx, prefix)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list source keys under %q: %w", prefix, err)
+	}
+
+	for i, info := range infos {
+		data, err := src.Get(ctx, info.Key)
+		if err != nil {
+			return i, fmt.Errorf("failed to read %q from source: %w", info.Key, err)
+		}
+		if err := dst.Put(ctx, info.Key, data); err != nil {
+			return i, fmt.Errorf("failed to write %q to destination: %w", info.Key, err)
+		}
+	}
+
+	return len(infos), nil
+}
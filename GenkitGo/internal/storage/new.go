@@ -0,0 +1,48 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+)
+
+// Config carries the subset of internal/config.Config that selects and
+// configures a storage driver - kept separate from config.Config so this
+// package doesn't import it back.
+type Config struct {
+	Backend   string // "local" (default) or "s3"
+	LocalDir  string
+	Endpoint  string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+	UseSSL    bool
+
+	// CacheReads wraps the selected backend in a CachingStorage, so
+	// repeated reads of the same key (a template, a page result) don't
+	// round-trip to a remote backend every time. Most useful for "s3";
+	// harmless but unnecessary for "local".
+	CacheReads bool
+}
+
+// New builds the Storage driver selected by cfg.Backend.
+func New(ctx context.Context, cfg Config) (Storage, error) {
+	backend, err := newBackend(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.CacheReads {
+		return NewCachingStorage(backend), nil
+	}
+	return backend, nil
+}
+
+func newBackend(ctx context.Context, cfg Config) (Storage, error) {
+	switch cfg.Backend {
+	case "", "local":
+		return NewLocalDriver(cfg.LocalDir)
+	case "s3":
+		return NewS3Driver(ctx, cfg.Endpoint, cfg.Bucket, cfg.AccessKey, cfg.SecretKey, cfg.UseSSL)
+	default:
+		return nil, fmt.Errorf("unknown storage backend: %q", cfg.Backend)
+	}
+}
@@ -0,0 +1,107 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+// S3Driver implements Storage against any S3-compatible endpoint (MinIO,
+// AWS S3, ...) via minio-go, so OCR artifacts can be shared across
+// multiple stateless API instances instead of living on one machine's
+// disk.
+type S3Driver struct {
+	client *minio.Client
+	bucket string
+}
+
+// NewS3Driver connects to an S3-compatible endpoint and ensures bucket
+// exists, creating it if it doesn't.
+func NewS3Driver(ctx context.Context, endpoint, bucket, accessKey, secretKey string, useSSL bool) (*S3Driver, error) {
+	client, err := minio.New(endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(accessKey, secretKey, ""),
+		Secure: useSSL,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create S3 client: %w", err)
+	}
+
+	exists, err := client.BucketExists(ctx, bucket)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check bucket %s: %w", bucket, err)
+	}
+	if !exists {
+		if err := client.MakeBucket(ctx, bucket, minio.MakeBucketOptions{}); err != nil {
+			return nil, fmt.Errorf("failed to create bucket %s: %w", bucket, err)
+		}
+	}
+
+	return &S3Driver{client: client, bucket: bucket}, nil
+}
+
+func (d *S3Driver) Put(ctx context.Context, key string, data []byte) error {
+	_, err := d.client.PutObject(ctx, d.bucket, key, bytes.NewReader(data), int64(len(data)), minio.PutObjectOptions{
+		ContentType: "application/octet-stream",
+	})
+	if err != nil {
+		return fmt.Errorf("failed to put %s: %w", key, err)
+	}
+	return nil
+}
+
+func (d *S3Driver) Get(ctx context.Context, key string) ([]byte, error) {
+	obj, err := d.client.GetObject(ctx, d.bucket, key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s: %w", key, err)
+	}
+	defer obj.Close()
+
+	data, err := io.ReadAll(obj)
+	if err != nil {
+		if isNoSuchKey(err) {
+			return nil, ErrNotExist
+		}
+		return nil, fmt.Errorf("failed to read %s: %w", key, err)
+	}
+	return data, nil
+}
+
+func (d *S3Driver) Delete(ctx context.Context, key string) error {
+	if err := d.client.RemoveObject(ctx, d.bucket, key, minio.RemoveObjectOptions{}); err != nil && !isNoSuchKey(err) {
+		return fmt.Errorf("failed to delete %s: %w", key, err)
+	}
+	return nil
+}
+
+func (d *S3Driver) List(ctx context.Context, prefix string) ([]Info, error) {
+	var infos []Info
+	for obj := range d.client.ListObjects(ctx, d.bucket, minio.ListObjectsOptions{Prefix: prefix, Recursive: true}) {
+		if obj.Err != nil {
+			return nil, fmt.Errorf("failed to list %s: %w", prefix, obj.Err)
+		}
+		infos = append(infos, Info{Key: obj.Key, Size: obj.Size, LastModified: obj.LastModified})
+	}
+	return infos, nil
+}
+
+func (d *S3Driver) Stat(ctx context.Context, key string) (*Info, error) {
+	info, err := d.client.StatObject(ctx, d.bucket, key, minio.StatObjectOptions{})
+	if err != nil {
+		if isNoSuchKey(err) {
+			return nil, ErrNotExist
+		}
+		return nil, fmt.Errorf("failed to stat %s: %w", key, err)
+	}
+	return &Info{Key: key, Size: info.Size, LastModified: info.LastModified}, nil
+}
+
+// isNoSuchKey reports whether err is the S3 "object not found" error, the
+// S3-equivalent of os.IsNotExist.
+func isNoSuchKey(err error) bool {
+	resp := minio.ToErrorResponse(err)
+	return resp.Code == "NoSuchKey"
+}
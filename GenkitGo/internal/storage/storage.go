@@ -0,0 +1,36 @@
+// Package storage abstracts where OCR artifacts (request metadata, page
+// results, templates, images) physically live, so the services under
+// internal/services/* stop hard-coding filepath.Join against a local
+// baseDir and can instead run against a shared S3-compatible bucket -
+// the only way to let multiple stateless API instances serve the same
+// data.
+package storage
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrNotExist is returned by Get and Stat when key isn't present, so
+// callers can branch on "not found" the same way they used to check
+// os.IsNotExist, regardless of which driver is in use.
+var ErrNotExist = errors.New("storage: key does not exist")
+
+// Info describes one stored object.
+type Info struct {
+	Key          string
+	Size         int64
+	LastModified time.Time
+}
+
+// Storage is the minimal object-storage contract every service needs:
+// whole-object reads/writes keyed by a path-like string, deletion,
+// prefix listing, and metadata lookup without a full read.
+type Storage interface {
+	Put(ctx context.Context, key string, data []byte) error
+	Get(ctx context.Context, key string) ([]byte, error)
+	Delete(ctx context.Context, key string) error
+	List(ctx context.Context, prefix string) ([]Info, error)
+	Stat(ctx context.Context, key string) (*Info, error)
+}
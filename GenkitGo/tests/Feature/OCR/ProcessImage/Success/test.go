@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	processimage "github.com/plobin/genkitgo/internal/http/controllers/OCR/ProcessImage"
+	"github.com/plobin/genkitgo/internal/services/OCR/ExtractBlocks"
 )
 
 // Test_이미지_OCR_처리가_성공한다 - 성공 케이스 테스트 (1파일 1메서드 원칙)
@@ -25,7 +26,8 @@ func Test_이미지_OCR_처리가_성공한다(t *testing.T) {
 	w := httptest.NewRecorder()
 
 	// Act
-	processimage.Handle(w, req)
+	service := ExtractBlocks.NewService("")
+	processimage.Handle(service)(w, req)
 
 	// Assert
 	if w.Code != http.StatusOK {
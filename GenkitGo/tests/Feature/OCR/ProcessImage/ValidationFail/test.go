@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	processimage "github.com/plobin/genkitgo/internal/http/controllers/OCR/ProcessImage"
+	"github.com/plobin/genkitgo/internal/services/OCR/ExtractBlocks"
 )
 
 // Test_필수_필드_누락_시_검증이_실패한다 - 검증 실패 테스트 (1파일 1메서드 원칙)
@@ -23,7 +24,8 @@ func Test_필수_필드_누락_시_검증이_실패한다(t *testing.T) {
 	w := httptest.NewRecorder()
 
 	// Act
-	processimage.Handle(w, req)
+	service := ExtractBlocks.NewService("")
+	processimage.Handle(service)(w, req)
 
 	// Assert
 	if w.Code != http.StatusBadRequest {